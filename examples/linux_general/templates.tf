@@ -2,21 +2,27 @@ resource "zabbix_template" "base_linux_general" {
   host        = "Base_Linux_General"
   groups      = [zabbix_host_group.template_linux.name]
   description = "Linux general template without network and disk support"
-  macro = {
-    CPU_AVG                   = "85"
-    CPU_DISASTER              = "95"
-    CPU_HIGH                  = "90"
-    CPU_INTERVAL              = "60m"
-    CPU_LOAD_RATIO_AVG        = "2"
-    CPU_LOAD_RATIO_DISASTER   = "3"
-    CPU_LOAD_RATIO_HIGH       = "2.5"
-    CPU_LOAD_RATIO_INTERVAL   = "30m"
-    CPU_LOAD_RATIO_WARN       = "1.5"
-    CPU_WARN                  = "80"
-    MEMORY_PERCENTAGE_AVG     = "10"
-    MEMORY_PERCENTAGE_DISABLE = "2"
-    MEMORY_PERCENTAGE_HIGH    = "5"
-    MEMORY_PERCENTAGE_WARN    = "15"
+  dynamic "macro" {
+    for_each = {
+      CPU_AVG                   = "85"
+      CPU_DISASTER              = "95"
+      CPU_HIGH                  = "90"
+      CPU_INTERVAL              = "60m"
+      CPU_LOAD_RATIO_AVG        = "2"
+      CPU_LOAD_RATIO_DISASTER   = "3"
+      CPU_LOAD_RATIO_HIGH       = "2.5"
+      CPU_LOAD_RATIO_INTERVAL   = "30m"
+      CPU_LOAD_RATIO_WARN       = "1.5"
+      CPU_WARN                  = "80"
+      MEMORY_PERCENTAGE_AVG     = "10"
+      MEMORY_PERCENTAGE_DISABLE = "2"
+      MEMORY_PERCENTAGE_HIGH    = "5"
+      MEMORY_PERCENTAGE_WARN    = "15"
+    }
+    content {
+      name  = macro.key
+      value = macro.value
+    }
   }
 }
 
@@ -21,8 +21,9 @@ resource "zabbix_template" "demo_template" {
   name        = "template demo"
   description = "An exemple of template with item and trigger"
   groups      = [zabbix_host_group.demo_group.name]
-  macro = {
-    MACRO_TEMPLATE = "12"
+  macro {
+    name  = "MACRO_TEMPLATE"
+    value = "12"
   }
 }
 
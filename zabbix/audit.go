@@ -0,0 +1,213 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const redacted = "<redacted>"
+
+// redactedKeys are the params whose values are never logged
+var redactedKeys = map[string]bool{
+	"auth":                  true,
+	"password":              true,
+	"passwd":                true,
+	"snmp_community":        true,
+	"snmpv3_authpassphrase": true,
+	"snmpv3_privpassphrase": true,
+	"tls_psk":               true,
+	"token":                 true,
+}
+
+// rpcRequest and rpcResponse are the JSON-RPC messages of the Zabbix API
+type rpcRequest struct {
+	Method string      `json:"method"`
+	Params interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result interface{} `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
+// auditEntry is a line of the audit file
+type auditEntry struct {
+	Time        time.Time   `json:"time"`
+	Server      string      `json:"server"`
+	Method      string      `json:"method"`
+	DurationMS  int64       `json:"duration_ms"`
+	ResultCount int         `json:"result_count"`
+	ErrorCode   int         `json:"error_code,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	Params      interface{} `json:"params,omitempty"`
+}
+
+// auditLog writes a JSON line per request to a file created for each run of
+// the provider
+type auditLog struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+func newAuditLog(dir string) (*auditLog, error) {
+	name := fmt.Sprintf("zabbix-audit-%s-%d.jsonl", time.Now().UTC().Format("20060102T150405Z"), os.Getpid())
+	file, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create the audit file: %w", err)
+	}
+	log.Printf("[INFO] Zabbix requests are audited in %s", file.Name())
+	return &auditLog{file: file}, nil
+}
+
+func (a *auditLog) write(entry auditEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[WARN] Failed to audit the Zabbix request %s: %s", entry.Method, err)
+		return
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if _, err := a.file.Write(append(line, '\n')); err != nil {
+		log.Printf("[WARN] Failed to audit the Zabbix request %s: %s", entry.Method, err)
+	}
+}
+
+// rpcLogTransport logs the JSON-RPC requests of the Zabbix API without their
+// secrets, and writes them to the audit log when it is configured
+type rpcLogTransport struct {
+	transport http.RoundTripper
+	audit     *auditLog
+}
+
+func newRPCLogTransport(transport http.RoundTripper, audit *auditLog) *rpcLogTransport {
+	return &rpcLogTransport{transport: transport, audit: audit}
+}
+
+func (t *rpcLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var request rpcRequest
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		json.Unmarshal(body, &request)
+	}
+	params := redactParams(request.Params)
+
+	start := time.Now()
+	res, err := t.transport.RoundTrip(req)
+	duration := time.Since(start)
+
+	entry := auditEntry{
+		Time:       start.UTC(),
+		Server:     req.URL.Host,
+		Method:     request.Method,
+		DurationMS: duration.Milliseconds(),
+		Params:     params,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		t.log(entry)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	var response rpcResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		entry.Error = fmt.Sprintf("HTTP %d, invalid JSON-RPC response", res.StatusCode)
+	} else if response.Error != nil {
+		entry.ErrorCode = response.Error.Code
+		entry.Error = strings.TrimSpace(response.Error.Message + " " + response.Error.Data)
+	} else {
+		entry.ResultCount = countResults(response.Result)
+	}
+	t.log(entry)
+	return res, nil
+}
+
+func (t *rpcLogTransport) log(entry auditEntry) {
+	if entry.Error != "" {
+		log.Printf("[DEBUG] Zabbix request %s failed in %s with error %d: %s", entry.Method, time.Duration(entry.DurationMS)*time.Millisecond, entry.ErrorCode, entry.Error)
+	} else {
+		log.Printf("[DEBUG] Zabbix request %s returned %d results in %s", entry.Method, entry.ResultCount, time.Duration(entry.DurationMS)*time.Millisecond)
+	}
+	if params, err := json.Marshal(entry.Params); err == nil {
+		log.Printf("[TRACE] Zabbix request %s params: %s", entry.Method, params)
+	}
+
+	if t.audit != nil {
+		t.audit.write(entry)
+	}
+}
+
+// countResults returns the number of objects returned by a get, or of IDs
+// returned by a create, update or delete
+func countResults(result interface{}) int {
+	switch r := result.(type) {
+	case []interface{}:
+		return len(r)
+	case map[string]interface{}:
+		for key, value := range r {
+			if ids, ok := value.([]interface{}); ok && strings.HasSuffix(key, "ids") {
+				return len(ids)
+			}
+		}
+		return 1
+	case nil:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// redactParams returns a copy of the params without passwords, session IDs, PSKs
+// and values of secret macros
+func redactParams(params interface{}) interface{} {
+	switch p := params.(type) {
+	case map[string]interface{}:
+		redactedParams := make(map[string]interface{}, len(p))
+		for key, value := range p {
+			if redactedKeys[key] {
+				redactedParams[key] = redacted
+			} else {
+				redactedParams[key] = redactParams(value)
+			}
+		}
+		if _, ok := p["macro"]; ok && fmt.Sprint(p["type"]) == fmt.Sprint(macroTypeSecret) {
+			if _, ok := p["value"]; ok {
+				redactedParams["value"] = redacted
+			}
+		}
+		return redactedParams
+	case []interface{}:
+		redactedParams := make([]interface{}, len(p))
+		for i, value := range p {
+			redactedParams[i] = redactParams(value)
+		}
+		return redactedParams
+	default:
+		return params
+	}
+}
@@ -0,0 +1,95 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRPCLogTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request rpcRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		switch request.Method {
+		case "user.login":
+			w.Write([]byte(`{"jsonrpc":"2.0","result":"0424bd59b807674191e7d77572075f33","id":1}`))
+		case "usermacro.createglobal":
+			w.Write([]byte(`{"jsonrpc":"2.0","result":{"globalmacroids":["7","8"]},"id":2}`))
+		default:
+			w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params.","data":"No permissions."},"id":3}`))
+		}
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	dir := t.TempDir()
+	audit, err := newAuditLog(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: newRPCLogTransport(http.DefaultTransport, audit)}
+	requests := []string{
+		`{"jsonrpc":"2.0","method":"user.login","params":{"user":"Admin","password":"zabbix-password"},"id":1}`,
+		`{"jsonrpc":"2.0","method":"usermacro.createglobal","params":[{"macro":"{$A}","value":"macro-secret","type":1},{"macro":"{$B}","value":"visible","type":0}],"auth":"session-id","id":2}`,
+		`{"jsonrpc":"2.0","method":"host.update","params":{"hostid":"1","tls_psk":"psk-secret"},"auth":"session-id","id":3}`,
+		`{"jsonrpc":"2.0","method":"drule.create","params":[{"name":"SNMP","iprange":"10.0.0.0/24","dchecks":[{"type":"13","snmp_community":"community-secret","snmpv3_securityname":"monitoring","snmpv3_authpassphrase":"auth-passphrase","snmpv3_privpassphrase":"priv-passphrase"}]}],"auth":"session-id","id":4}`,
+	}
+	for _, request := range requests {
+		res, err := client.Post(server.URL, "application/json-rpc", strings.NewReader(request))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var response rpcResponse
+		if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+			t.Fatalf("expected the response to be passed through, got %s", err)
+		}
+		res.Body.Close()
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "zabbix-audit-*.jsonl"))
+	if len(files) != 1 {
+		t.Fatalf("expected an audit file, got %v", files)
+	}
+	content, _ := os.ReadFile(files[0])
+	for _, output := range []string{logs.String(), string(content)} {
+		for _, secret := range []string{"zabbix-password", "macro-secret", "psk-secret", "session-id", "0424bd59b807674191e7d77572075f33", "community-secret", "auth-passphrase", "priv-passphrase"} {
+			if strings.Contains(output, secret) {
+				t.Errorf("expected %s to be redacted from %s", secret, output)
+			}
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected an audit line per request, got %d", len(lines))
+	}
+	var entries []auditEntry
+	for _, line := range lines {
+		var entry auditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	if entries[1].Method != "usermacro.createglobal" || entries[1].ResultCount != 2 {
+		t.Errorf("expected 2 created macros, got %+v", entries[1])
+	}
+	if entries[2].ErrorCode != -32602 || entries[2].Error != "Invalid params. No permissions." {
+		t.Errorf("expected the error of the request, got %+v", entries[2])
+	}
+	if !strings.Contains(string(content), "visible") {
+		t.Error("expected the values of text macros to be audited")
+	}
+	if entries[3].Method != "drule.create" || !strings.Contains(lines[3], "monitoring") {
+		t.Errorf("expected the discovery rule to be audited without its secrets, got %s", lines[3])
+	}
+}
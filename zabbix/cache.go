@@ -0,0 +1,339 @@
+package zabbix
+
+import (
+	"log"
+	"sync"
+
+	"github.com/claranet/go-zabbix-api"
+)
+
+// lookupCache keeps the lookups shared by the resources of the provider, so that
+// refreshing many resources issues a bounded number of requests. Each lookup is
+// loaded with a single request and invalidated when the provider writes an
+// object of the same type.
+type lookupCache struct {
+	mutex sync.Mutex
+
+	hostGroups *nameLookup
+	templates  *nameLookup
+	// valueMaps are keyed by host ID from Zabbix 5.4, by "" before
+	valueMaps map[string]**nameLookup
+	// hosts is nil until the first host is read, the hosts are then all loaded
+	hosts map[string]*hostLookup
+	items map[string]*itemLookup
+}
+
+// nameLookup maps the names of objects to their IDs and back
+type nameLookup struct {
+	ids   map[string]string
+	names map[string]string
+}
+
+type hostLookup struct {
+	HostID          string              `json:"hostid"`
+	Host            string              `json:"host"`
+	Name            string              `json:"name"`
+	Status          int                 `json:"status,string"`
+	ParentTemplates zabbix.TemplateIDs  `json:"parentTemplates"`
+	Groups          zabbix.HostGroupIDs `json:"groups"`
+	HostGroups      zabbix.HostGroupIDs `json:"hostgroups"`
+}
+
+type itemLookup struct {
+	ItemID string `json:"itemid"`
+	Key    string `json:"key_"`
+	Hosts  []struct {
+		Host string `json:"host"`
+	} `json:"hosts"`
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{valueMaps: map[string]**nameLookup{}, items: map[string]*itemLookup{}}
+}
+
+func loadNameLookup(api *zabbix.API, method, idField, nameField string, params zabbix.Params) (*nameLookup, error) {
+	request := zabbix.Params{
+		"output": []string{idField, nameField},
+	}
+	for k, v := range params {
+		request[k] = v
+	}
+	var objects []map[string]string
+	err := api.CallWithErrorParse(method, request, &objects)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := &nameLookup{
+		ids:   make(map[string]string, len(objects)),
+		names: make(map[string]string, len(objects)),
+	}
+	for _, object := range objects {
+		lookup.ids[object[nameField]] = object[idField]
+		lookup.names[object[idField]] = object[nameField]
+	}
+	log.Printf("[DEBUG] Loaded %d objects with %s in the lookup cache", len(objects), method)
+	return lookup, nil
+}
+
+// getIDs returns the IDs of the names found in the lookup, the lookup is loaded
+// again once when some names are missing as they may have been created since
+func (c *lookupCache) getIDs(lookup **nameLookup, api *zabbix.API, method, idField, nameField string, params zabbix.Params, names []string) (map[string]string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	reloaded := false
+	if *lookup == nil {
+		l, err := loadNameLookup(api, method, idField, nameField, params)
+		if err != nil {
+			return nil, err
+		}
+		*lookup = l
+		reloaded = true
+	}
+
+	ids := make(map[string]string, len(names))
+	for _, name := range names {
+		id, ok := (*lookup).ids[name]
+		if !ok && !reloaded {
+			l, err := loadNameLookup(api, method, idField, nameField, params)
+			if err != nil {
+				return nil, err
+			}
+			*lookup = l
+			reloaded = true
+			id, ok = (*lookup).ids[name]
+		}
+		if ok {
+			ids[name] = id
+		}
+	}
+	return ids, nil
+}
+
+// getNames returns the names of the IDs found in the lookup, reloaded once
+// when an ID is missing
+func (c *lookupCache) getNames(lookup **nameLookup, api *zabbix.API, method, idField, nameField string, params zabbix.Params, ids []string) ([]string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	reloaded := false
+	if *lookup == nil {
+		l, err := loadNameLookup(api, method, idField, nameField, params)
+		if err != nil {
+			return nil, err
+		}
+		*lookup = l
+		reloaded = true
+	}
+
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		name, ok := (*lookup).names[id]
+		if !ok && !reloaded {
+			l, err := loadNameLookup(api, method, idField, nameField, params)
+			if err != nil {
+				return nil, err
+			}
+			*lookup = l
+			reloaded = true
+			name, ok = (*lookup).names[id]
+		}
+		if ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (c *lookupCache) getHostGroupIDs(api *zabbix.API, names []string) (map[string]string, error) {
+	return c.getIDs(&c.hostGroups, api, "hostgroup.get", "groupid", "name", nil, names)
+}
+
+func (c *lookupCache) getHostGroupNames(api *zabbix.API, ids []string) ([]string, error) {
+	return c.getNames(&c.hostGroups, api, "hostgroup.get", "groupid", "name", nil, ids)
+}
+
+func (c *lookupCache) getTemplateIDs(api *zabbix.API, names []string) (map[string]string, error) {
+	return c.getIDs(&c.templates, api, "template.get", "templateid", "host", nil, names)
+}
+
+func (c *lookupCache) getTemplateNames(api *zabbix.API, ids []string) ([]string, error) {
+	return c.getNames(&c.templates, api, "template.get", "templateid", "host", nil, ids)
+}
+
+// getValueMapIDs returns the IDs of the value maps of the host, or of the global
+// value maps before Zabbix 5.4, found in the lookup
+func (c *lookupCache) getValueMapIDs(api *zabbix.API, zabbixVersion, hostID string, names []string) (map[string]string, error) {
+	key, params := "", zabbix.Params{}
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.4.0") {
+		key = hostID
+		params["hostids"] = hostID
+	}
+
+	c.mutex.Lock()
+	lookup, ok := c.valueMaps[key]
+	if !ok {
+		lookup = new(*nameLookup)
+		c.valueMaps[key] = lookup
+	}
+	c.mutex.Unlock()
+
+	return c.getIDs(lookup, api, "valuemap.get", "valuemapid", "name", params, names)
+}
+
+func getHosts(api *zabbix.API, zabbixVersion string, params zabbix.Params) ([]hostLookup, error) {
+	params["output"] = []string{"hostid", "host", "name", "status"}
+	params["selectParentTemplates"] = []string{"templateid"}
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.2.0") {
+		params["selectHostGroups"] = []string{"groupid"}
+	} else {
+		params["selectGroups"] = []string{"groupid"}
+	}
+
+	var hosts []hostLookup
+	err := api.CallWithErrorParse("host.get", params, &hosts)
+	return hosts, err
+}
+
+// getHost returns the host with the given ID, all the hosts are loaded on the
+// first call and the hosts written since are requested one by one
+func (c *lookupCache) getHost(api *zabbix.API, zabbixVersion, id string) (*hostLookup, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.hosts == nil {
+		hosts, err := getHosts(api, zabbixVersion, zabbix.Params{})
+		if err != nil {
+			return nil, err
+		}
+		c.hosts = make(map[string]*hostLookup, len(hosts))
+		for i := range hosts {
+			c.hosts[hosts[i].HostID] = &hosts[i]
+		}
+		log.Printf("[DEBUG] Loaded %d hosts in the lookup cache", len(hosts))
+	}
+
+	if host, ok := c.hosts[id]; ok {
+		return host, nil
+	}
+
+	hosts, err := getHosts(api, zabbixVersion, zabbix.Params{"hostids": id})
+	if err != nil {
+		return nil, err
+	}
+	err = expectOneResult("Host", id, len(hosts))
+	if err != nil {
+		return nil, err
+	}
+	c.hosts[id] = &hosts[0]
+	return &hosts[0], nil
+}
+
+// getItems returns the items with the given IDs, the items of the given hosts are
+// loaded when some are missing so that the other triggers of these hosts are
+// resolved without requests
+func (c *lookupCache) getItems(api *zabbix.API, method string, hostIDs, itemIDs []string) (map[string]*itemLookup, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	missingIDs := c.getMissingItemIDs(itemIDs)
+	if len(missingIDs) > 0 && len(hostIDs) > 0 {
+		if err := c.loadItems(api, method, zabbix.Params{"hostids": hostIDs}); err != nil {
+			return nil, err
+		}
+		missingIDs = c.getMissingItemIDs(itemIDs)
+	}
+	if len(missingIDs) > 0 {
+		if err := c.loadItems(api, method, zabbix.Params{"itemids": missingIDs}); err != nil {
+			return nil, err
+		}
+	}
+
+	items := make(map[string]*itemLookup, len(itemIDs))
+	for _, id := range itemIDs {
+		if item, ok := c.items[id]; ok {
+			items[id] = item
+		}
+	}
+	return items, nil
+}
+
+func (c *lookupCache) getMissingItemIDs(itemIDs []string) []string {
+	var missingIDs []string
+	for _, id := range itemIDs {
+		if _, ok := c.items[id]; !ok {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+	return missingIDs
+}
+
+func (c *lookupCache) loadItems(api *zabbix.API, method string, params zabbix.Params) error {
+	params["output"] = []string{"itemid", "key_"}
+	params["selectHosts"] = []string{"host"}
+
+	var items []itemLookup
+	err := api.CallWithErrorParse(method, params, &items)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		c.items[items[i].ItemID] = &items[i]
+	}
+	log.Printf("[DEBUG] Loaded %d items with %s in the lookup cache", len(items), method)
+	return nil
+}
+
+func (c *lookupCache) invalidateHostGroups() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.hostGroups = nil
+}
+
+// invalidateTemplates also drops the items, their hosts may be renamed templates
+func (c *lookupCache) invalidateTemplates() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.templates = nil
+	c.items = map[string]*itemLookup{}
+}
+
+// invalidateHost also drops the items, their host may be the renamed host
+func (c *lookupCache) invalidateHost(id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.hosts != nil {
+		delete(c.hosts, id)
+	}
+	c.items = map[string]*itemLookup{}
+}
+
+func (c *lookupCache) invalidateValueMaps() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.valueMaps = map[string]**nameLookup{}
+}
+
+func (c *lookupCache) invalidateItems() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.items = map[string]*itemLookup{}
+}
+
+// invalidate drops all the lookups, used after writes of several object types
+func (c *lookupCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.hostGroups = nil
+	c.templates = nil
+	c.valueMaps = map[string]**nameLookup{}
+	c.hosts = nil
+	c.items = map[string]*itemLookup{}
+}
@@ -0,0 +1,167 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/claranet/go-zabbix-api"
+)
+
+func TestLookupCacheHostGroups(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.Method != "hostgroup.get" {
+			t.Errorf("expected a hostgroup.get request, got %s", request.Method)
+		}
+		requests++
+		w.Write([]byte(`{"jsonrpc":"2.0","result":[{"groupid":"1","name":"Linux servers"},{"groupid":"2","name":"Databases"}],"id":1}`))
+	}))
+	defer server.Close()
+
+	api := zabbix.NewAPI(server.URL)
+	cache := newLookupCache()
+
+	for i := 0; i < 3; i++ {
+		ids, err := cache.getHostGroupIDs(api, []string{"Linux servers", "Databases"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ids["Linux servers"] != "1" || ids["Databases"] != "2" {
+			t.Fatalf("expected the IDs of the host groups, got %v", ids)
+		}
+	}
+	names, err := cache.getHostGroupNames(api, []string{"2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != "Databases" {
+		t.Fatalf("expected the name of the host group, got %v", names)
+	}
+	if requests != 1 {
+		t.Fatalf("expected the host groups to be loaded once, got %d requests", requests)
+	}
+
+	ids, err := cache.getHostGroupIDs(api, []string{"Missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ids["Missing"]; ok || requests != 2 {
+		t.Fatalf("expected a missing host group to be loaded again once, got %v with %d requests", ids, requests)
+	}
+
+	cache.invalidateHostGroups()
+	if _, err := cache.getHostGroupIDs(api, []string{"Databases"}); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Fatalf("expected the host groups to be loaded again after a write, got %d requests", requests)
+	}
+
+	names, err = cache.getHostGroupNames(api, []string{"3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 0 || requests != 4 {
+		t.Fatalf("expected a missing host group ID to be loaded again once, got %v with %d requests", names, requests)
+	}
+}
+
+func TestLookupCacheItems(t *testing.T) {
+	var params []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Params map[string]interface{} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		params = append(params, request.Params)
+		w.Write([]byte(`{"jsonrpc":"2.0","result":[{"itemid":"10","key_":"system.cpu.load","hosts":[{"host":"Linux"}]},{"itemid":"11","key_":"vm.memory.size","hosts":[{"host":"Linux"}]}],"id":1}`))
+	}))
+	defer server.Close()
+
+	api := zabbix.NewAPI(server.URL)
+	cache := newLookupCache()
+
+	for _, id := range []string{"10", "11"} {
+		items, err := cache.getItems(api, "item.get", []string{"100"}, []string{id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if item, ok := items[id]; !ok || item.Hosts[0].Host != "Linux" {
+			t.Fatalf("expected item %s on Linux, got %v", id, items)
+		}
+	}
+	if len(params) != 1 || params[0]["hostids"] == nil {
+		t.Fatalf("expected the items to be loaded once by host, got %v", params)
+	}
+
+	expression, err := expandTriggerExpression("{1}>5 and {2}<100", zabbix.TriggerFunctions{
+		{FunctionID: "1", ItemID: "10", Function: "last", Parameter: ""},
+		{FunctionID: "2", ItemID: "11", Function: "avg", Parameter: "5m"},
+	}, zabbix.Hosts{{HostID: "100"}}, "item.get", api, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expression != "{Linux:system.cpu.load.last()}>5 and {Linux:vm.memory.size.avg(5m)}<100" {
+		t.Fatalf("unexpected expression %s", expression)
+	}
+	if len(params) != 1 {
+		t.Fatalf("expected the expression to be expanded from the cache, got %d requests", len(params))
+	}
+}
+
+func TestLookupCacheValueMaps(t *testing.T) {
+	var params []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Params map[string]interface{} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		params = append(params, request.Params)
+		w.Write([]byte(`{"jsonrpc":"2.0","result":[{"valuemapid":"5","name":"Service state"}],"id":1}`))
+	}))
+	defer server.Close()
+
+	api := zabbix.NewAPI(server.URL)
+	cache := newLookupCache()
+
+	for i := 0; i < 3; i++ {
+		id, err := getValueMapID(api, cache, "6.0.0", "10084", "Service state")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != "5" {
+			t.Fatalf("expected the ID of the value map, got %s", id)
+		}
+	}
+	if len(params) != 1 || params[0]["hostids"] != "10084" {
+		t.Fatalf("expected the value maps of the host to be loaded once, got %v", params)
+	}
+
+	if _, err := getValueMapID(api, cache, "6.0.0", "10085", "Service state"); err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 2 || params[1]["hostids"] != "10085" {
+		t.Fatalf("expected the value maps of another host to be loaded, got %v", params)
+	}
+
+	if _, err := getValueMapID(api, cache, "5.0.0", "10084", "Missing"); err == nil {
+		t.Fatal("expected a missing value map to fail")
+	}
+	if len(params) != 3 || params[2]["hostids"] != nil {
+		t.Fatalf("expected the global value maps to be loaded once before Zabbix 5.4, got %v", params)
+	}
+
+	cache.invalidateValueMaps()
+	if _, err := getValueMapID(api, cache, "6.0.0", "10084", "Service state"); err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 4 {
+		t.Fatalf("expected the value maps to be loaded again after a write, got %d requests", len(params))
+	}
+}
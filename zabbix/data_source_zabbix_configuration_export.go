@@ -0,0 +1,81 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceZabbixConfigurationExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZabbixConfigurationExportRead,
+		Schema: map[string]*schema.Schema{
+			"format": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "json",
+				Description: "Format of the configuration: xml, json or yaml (Zabbix 5.2+).",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if _, ok := configurationDateRegexps[v]; !ok {
+						errs = append(errs, fmt.Errorf("%q, must be one of xml, json or yaml, got %s", key, v))
+					}
+					return
+				},
+			},
+			"template_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "IDs of the templates to export.",
+			},
+			"host_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "IDs of the hosts to export.",
+			},
+			"document": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Exported configuration.",
+			},
+		},
+	}
+}
+
+func dataSourceZabbixConfigurationExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	templateIDs := getSortedStrings(d.Get("template_ids").(*schema.Set))
+	hostIDs := getSortedStrings(d.Get("host_ids").(*schema.Set))
+	if len(templateIDs) == 0 && len(hostIDs) == 0 {
+		return diag.Errorf("At least one of template_ids or host_ids must be set")
+	}
+
+	format := d.Get("format").(string)
+	document, err := exportConfiguration(api, format, templateIDs, hostIDs)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId(createDataSourceListID(append(templateIDs, hostIDs...)))
+	d.Set("document", document)
+
+	log.Printf("[DEBUG] Exported %d templates and %d hosts\n", len(templateIDs), len(hostIDs))
+	return nil
+}
+
+func getSortedStrings(set *schema.Set) []string {
+	values := make([]string, set.Len())
+	for i, v := range set.List() {
+		values[i] = v.(string)
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,46 @@
+package zabbix
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccZabbixDataSourceConfigurationExport_Basic(t *testing.T) {
+	strID := acctest.RandString(5)
+	templateName := fmt.Sprintf("template_%s", strID)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixDataSourceConfigurationExportConfig(strID, templateName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.zabbix_configuration_export.export_test", "document", regexp.MustCompile(`"zabbix_export"`)),
+					resource.TestMatchResourceAttr("data.zabbix_configuration_export.export_test", "document", regexp.MustCompile(templateName)),
+				),
+			},
+		},
+	})
+}
+
+func testAccZabbixDataSourceConfigurationExportConfig(strID, templateName string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_template_group" "host_group_test" {
+			name = "host group %s"
+		}
+
+		resource "zabbix_template" "template_test" {
+			host = "%s"
+			groups = [zabbix_template_group.host_group_test.name]
+		}
+
+		data "zabbix_configuration_export" "export_test" {
+			template_ids = [zabbix_template.template_test.id]
+		}
+	`, strID, templateName)
+}
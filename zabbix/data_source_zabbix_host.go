@@ -0,0 +1,462 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// hostData represent the Zabbix host object returned by host.get
+// https://www.zabbix.com/documentation/current/manual/api/reference/host/object
+type hostData struct {
+	HostID          string              `json:"hostid"`
+	Host            string              `json:"host"`
+	Name            string              `json:"name"`
+	Status          string              `json:"status"`
+	Description     string              `json:"description"`
+	Interfaces      []hostInterfaceData `json:"interfaces"`
+	ParentTemplates []templateRef       `json:"parentTemplates"`
+	Groups          []zabbix.HostGroup  `json:"groups"`
+	HostGroups      []zabbix.HostGroup  `json:"hostgroups"`
+	Macros          []userMacroData     `json:"macros"`
+	Tags            []tagData           `json:"tags"`
+}
+
+// hostInterfaceData represent Zabbix host interface object
+// https://www.zabbix.com/documentation/current/manual/api/reference/hostinterface/object
+type hostInterfaceData struct {
+	InterfaceID string `json:"interfaceid"`
+	Type        string `json:"type"`
+	IP          string `json:"ip"`
+	DNS         string `json:"dns"`
+	Port        string `json:"port"`
+	Main        string `json:"main"`
+}
+
+type templateRef struct {
+	TemplateID string `json:"templateid"`
+	Host       string `json:"host"`
+	Name       string `json:"name"`
+}
+
+type userMacroData struct {
+	Macro       string `json:"macro"`
+	Value       string `json:"value"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+type tagData struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+func dataSourceZabbixHost() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZabbixHostRead,
+		Schema: mergeSchemas(schemaHostFilter(), map[string]*schema.Schema{
+			"host": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Technical name of the host.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Visible name of the host.",
+			},
+		}, schemaHostData()),
+	}
+}
+
+// schemaHostFilter returns the arguments used to look up hosts
+func schemaHostFilter() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Technical name of the host.",
+		},
+		"name": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Visible name of the host.",
+		},
+		"groups": &schema.Schema{
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "Names of the host groups, the host must belong to at least one of them.",
+		},
+		"tag": schemaTagFilter(),
+	}
+}
+
+// schemaHostData returns the attributes exported for a host
+func schemaHostData() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"monitored": &schema.Schema{
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"description": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"interfaces": &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"interface_id": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"type": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"ip": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"dns": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"port": &schema.Schema{
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"main": &schema.Schema{
+						Type:     schema.TypeBool,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"group_ids": &schema.Schema{
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+		},
+		"template_ids": &schema.Schema{
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+		},
+		"templates": &schema.Schema{
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Computed:    true,
+			Description: "Technical names of the linked templates.",
+		},
+		"macros": schemaMacrosData(),
+		"tags":   schemaTagsData(),
+	}
+}
+
+// schemaTagFilter returns the tag block used to look up objects by tags
+func schemaTagFilter() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tag": &schema.Schema{
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"value": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "",
+				},
+				"operator": &schema.Schema{
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     0,
+					Description: "0 (contains), 1 (equals), 2 (does not contain), 3 (does not equal), 4 (exists), 5 (does not exist).",
+					ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+						v := val.(int)
+						if v < 0 || v > 5 {
+							errs = append(errs, fmt.Errorf("%q, must be between 0 and 5 inclusive, got %d", key, v))
+						}
+						return
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaMacrosData() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"value": &schema.Schema{
+					Type:      schema.TypeString,
+					Computed:  true,
+					Sensitive: true,
+				},
+				"type": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"description": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func schemaTagsData() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tag": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"value": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// mergeSchemas returns a new schema map with the attributes of all schemas,
+// the last schema defining an attribute wins
+func mergeSchemas(schemas ...map[string]*schema.Schema) map[string]*schema.Schema {
+	merged := map[string]*schema.Schema{}
+	for _, s := range schemas {
+		for key, value := range s {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+func dataSourceZabbixHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	hosts, err := getHostsData(d, api, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if len(hosts) != 1 {
+		return diag.Errorf("Expected one host matching the filters and got %d", len(hosts))
+	}
+
+	host := hosts[0]
+	d.SetId(host.HostID)
+	for key, value := range flattenHostData(host) {
+		d.Set(key, value)
+	}
+
+	log.Printf("[DEBUG] Found host %s with id %s\n", host.Host, host.HostID)
+	return nil
+}
+
+// getHostsData returns the hosts matching the filters of schemaHostFilter
+func getHostsData(d *schema.ResourceData, api *zabbix.API, zabbixVersion string) ([]hostData, error) {
+	params := zabbix.Params{
+		"output":                "extend",
+		"selectInterfaces":      "extend",
+		"selectParentTemplates": []string{"templateid", "host", "name"},
+		"selectMacros":          "extend",
+	}
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.2.0") {
+		params["selectHostGroups"] = []string{"groupid", "name"}
+	} else {
+		params["selectGroups"] = []string{"groupid", "name"}
+	}
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "4.2.0") {
+		params["selectTags"] = "extend"
+	}
+
+	filter := map[string]interface{}{}
+	if v, ok := d.GetOk("host"); ok {
+		filter["host"] = v.(string)
+	}
+	if v, ok := d.GetOk("name"); ok {
+		filter["name"] = v.(string)
+	}
+	if len(filter) > 0 {
+		params["filter"] = filter
+	}
+
+	if v, ok := d.GetOk("groups"); ok {
+		groupIDs, err := getGroupIDsByName(api, "hostgroup.get", v.(*schema.Set))
+		if err != nil {
+			return nil, err
+		}
+		params["groupids"] = groupIDs
+	}
+
+	if v, ok := d.GetOk("tag"); ok {
+		if !isZabbixServerVersionGreaterOrEqual(zabbixVersion, "4.2.0") {
+			return nil, fmt.Errorf("Looking up hosts by tags requires Zabbix 4.2 or higher, server version is %s", zabbixVersion)
+		}
+		params["tags"] = createTagFilter(v.(*schema.Set))
+	}
+
+	var hosts []hostData
+	err := api.CallWithErrorParse("host.get", params, &hosts)
+	return hosts, err
+}
+
+// getGroupIDsByName returns the IDs of the groups named in names, method is
+// the get method of the group kind, e.g. hostgroup.get
+func getGroupIDsByName(api *zabbix.API, method string, names *schema.Set) ([]string, error) {
+	var groups []zabbix.HostGroup
+
+	err := api.CallWithErrorParse(method, zabbix.Params{
+		"output": []string{"groupid", "name"},
+		"filter": map[string]interface{}{
+			"name": names.List(),
+		},
+	}, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names.List() {
+		found := false
+		for _, g := range groups {
+			if g.Name == name.(string) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Group %s doesnt exist in zabbix server", name)
+		}
+	}
+
+	groupIDs := make([]string, len(groups))
+	for i, g := range groups {
+		groupIDs[i] = g.GroupID
+	}
+	return groupIDs, nil
+}
+
+func createTagFilter(tags *schema.Set) []map[string]interface{} {
+	filter := make([]map[string]interface{}, tags.Len())
+	for i, t := range tags.List() {
+		tag := t.(map[string]interface{})
+		filter[i] = map[string]interface{}{
+			"tag":      tag["tag"].(string),
+			"value":    tag["value"].(string),
+			"operator": strconv.Itoa(tag["operator"].(int)),
+		}
+	}
+	return filter
+}
+
+// flattenHostData returns the attributes of schemaHostData for host
+func flattenHostData(host hostData) map[string]interface{} {
+	interfaceTypes := map[string]string{}
+	for name, id := range HostInterfaceTypes {
+		interfaceTypes[strconv.Itoa(int(id))] = name
+	}
+
+	interfaces := make([]interface{}, len(host.Interfaces))
+	for i, hostInterface := range host.Interfaces {
+		interfaces[i] = map[string]interface{}{
+			"interface_id": hostInterface.InterfaceID,
+			"type":         interfaceTypes[hostInterface.Type],
+			"ip":           hostInterface.IP,
+			"dns":          hostInterface.DNS,
+			"port":         hostInterface.Port,
+			"main":         hostInterface.Main == "1",
+		}
+	}
+
+	templateIDs, templateNames := flattenTemplateRefs(host.ParentTemplates)
+	return map[string]interface{}{
+		"host_id":      host.HostID,
+		"host":         host.Host,
+		"name":         host.Name,
+		"monitored":    host.Status == "0",
+		"description":  host.Description,
+		"interfaces":   interfaces,
+		"group_ids":    flattenGroupIDs(append(host.Groups, host.HostGroups...)),
+		"template_ids": templateIDs,
+		"templates":    templateNames,
+		"macros":       flattenMacrosData(host.Macros),
+		"tags":         flattenTagsData(host.Tags),
+	}
+}
+
+func flattenGroupIDs(groups []zabbix.HostGroup) []string {
+	groupIDs := make([]string, len(groups))
+	for i, g := range groups {
+		groupIDs[i] = g.GroupID
+	}
+	return groupIDs
+}
+
+func flattenTemplateRefs(templates []templateRef) ([]string, []string) {
+	templateIDs := make([]string, len(templates))
+	templateNames := make([]string, len(templates))
+	for i, t := range templates {
+		templateIDs[i] = t.TemplateID
+		templateNames[i] = t.Host
+	}
+	return templateIDs, templateNames
+}
+
+func flattenMacrosData(macros []userMacroData) []interface{} {
+	terraformMacros := make([]interface{}, 0, len(macros))
+	for _, m := range macros {
+		name, err := getTerraformMacroName(m.Macro)
+		if err != nil {
+			log.Printf("[DEBUG] Ignoring macro %s: %s", m.Macro, err)
+			continue
+		}
+		macroType, _ := strconv.Atoi(m.Type)
+		terraformMacros = append(terraformMacros, map[string]interface{}{
+			"name":        name,
+			"value":       m.Value,
+			"type":        macroType,
+			"description": m.Description,
+		})
+	}
+	return terraformMacros
+}
+
+func flattenTagsData(tags []tagData) []interface{} {
+	terraformTags := make([]interface{}, len(tags))
+	for i, t := range tags {
+		terraformTags[i] = map[string]interface{}{
+			"tag":   t.Tag,
+			"value": t.Value,
+		}
+	}
+	return terraformTags
+}
@@ -0,0 +1,58 @@
+package zabbix
+
+import (
+	"context"
+	"log"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceZabbixHostGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZabbixHostGroupRead,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the host group.",
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"internal": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the host group is internal to Zabbix and can't be deleted.",
+			},
+		},
+	}
+}
+
+func dataSourceZabbixHostGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	groups, err := api.HostGroupsGet(zabbix.Params{
+		"output": "extend",
+		"filter": map[string]interface{}{
+			"name": d.Get("name").(string),
+		},
+	})
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if len(groups) != 1 {
+		return diag.Errorf("Expected one host group named %s and got %d", d.Get("name").(string), len(groups))
+	}
+
+	group := groups[0]
+	d.SetId(group.GroupID)
+	d.Set("group_id", group.GroupID)
+	d.Set("internal", group.Internal == zabbix.Internal)
+
+	log.Printf("[DEBUG] Found host group %s with id %s\n", group.Name, group.GroupID)
+	return nil
+}
@@ -0,0 +1,56 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccZabbixDataSourceHostGroup_Basic(t *testing.T) {
+	strID := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixDataSourceHostGroupConfig(strID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.zabbix_host_group.by_name", "id", "zabbix_host_group.first", "id"),
+					resource.TestCheckResourceAttr("data.zabbix_host_group.by_name", "internal", "false"),
+					resource.TestCheckResourceAttr("data.zabbix_host_groups.by_search", "groups.#", "2"),
+					resource.TestCheckResourceAttr("data.zabbix_host_groups.by_search", "groups.0.name", fmt.Sprintf("data source %s first", strID)),
+					resource.TestCheckResourceAttr("data.zabbix_host_groups.by_names", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.zabbix_host_groups.by_names", "ids.0", "zabbix_host_group.second", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccZabbixDataSourceHostGroupConfig(strID string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_host_group" "first" {
+			name = "data source %s first"
+		}
+
+		resource "zabbix_host_group" "second" {
+			name = "data source %s second"
+		}
+
+		data "zabbix_host_group" "by_name" {
+			name = zabbix_host_group.first.name
+		}
+
+		data "zabbix_host_groups" "by_search" {
+			search = "data source %s *"
+			depends_on = [zabbix_host_group.first, zabbix_host_group.second]
+		}
+
+		data "zabbix_host_groups" "by_names" {
+			names = [zabbix_host_group.second.name]
+		}
+	`, strID, strID, strID)
+}
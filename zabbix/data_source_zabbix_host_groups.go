@@ -0,0 +1,111 @@
+package zabbix
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceZabbixHostGroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZabbixHostGroupsRead,
+		Schema: map[string]*schema.Schema{
+			"names": &schema.Schema{
+				Type:          schema.TypeSet,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Optional:      true,
+				ConflictsWith: []string{"search"},
+				Description:   "Exact names of the host groups.",
+			},
+			"search": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"names"},
+				Description:   "Pattern matched against the names of the host groups, * is a wildcard.",
+			},
+			"host_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Only return host groups containing at least one of these hosts.",
+			},
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "IDs of the host groups matching the filters.",
+			},
+			"groups": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"internal": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceZabbixHostGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	params := zabbix.Params{
+		"output": "extend",
+	}
+	if v, ok := d.GetOk("names"); ok {
+		params["filter"] = map[string]interface{}{
+			"name": v.(*schema.Set).List(),
+		}
+	}
+	if v, ok := d.GetOk("search"); ok {
+		params["search"] = map[string]interface{}{
+			"name": v.(string),
+		}
+		params["searchWildcardsEnabled"] = true
+	}
+	if v, ok := d.GetOk("host_ids"); ok {
+		params["hostids"] = v.(*schema.Set).List()
+	}
+
+	groups, err := api.HostGroupsGet(params)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+
+	ids := make([]string, len(groups))
+	terraformGroups := make([]interface{}, len(groups))
+	for i, group := range groups {
+		ids[i] = group.GroupID
+		terraformGroups[i] = map[string]interface{}{
+			"group_id": group.GroupID,
+			"name":     group.Name,
+			"internal": group.Internal == zabbix.Internal,
+		}
+	}
+
+	d.SetId(createDataSourceListID(ids))
+	d.Set("ids", ids)
+	d.Set("groups", terraformGroups)
+
+	log.Printf("[DEBUG] Found %d host groups\n", len(groups))
+	return nil
+}
@@ -0,0 +1,80 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccZabbixDataSourceHost_Basic(t *testing.T) {
+	strID := acctest.RandString(5)
+	host := fmt.Sprintf("host_%s", strID)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixDataSourceHostConfig(strID, host),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.zabbix_host.by_host", "id", "zabbix_host.host_test", "id"),
+					resource.TestCheckResourceAttr("data.zabbix_host.by_host", "name", "Host "+strID),
+					resource.TestCheckResourceAttr("data.zabbix_host.by_host", "monitored", "true"),
+					resource.TestCheckResourceAttr("data.zabbix_host.by_host", "interfaces.#", "1"),
+					resource.TestCheckResourceAttr("data.zabbix_host.by_host", "interfaces.0.ip", "127.0.0.1"),
+					resource.TestCheckResourceAttr("data.zabbix_host.by_host", "interfaces.0.type", "agent"),
+					resource.TestCheckResourceAttrSet("data.zabbix_host.by_host", "interfaces.0.interface_id"),
+					resource.TestCheckResourceAttrPair("data.zabbix_host.by_host", "group_ids.0", "zabbix_host_group.host_group_test", "id"),
+					resource.TestCheckResourceAttr("data.zabbix_host.by_host", "templates.#", "1"),
+					resource.TestCheckResourceAttrPair("data.zabbix_host.by_name", "id", "zabbix_host.host_test", "id"),
+					resource.TestCheckResourceAttr("data.zabbix_hosts.by_group", "hosts.#", "1"),
+					resource.TestCheckResourceAttr("data.zabbix_hosts.by_group", "hosts.0.host", host),
+					resource.TestCheckResourceAttrPair("data.zabbix_hosts.by_group", "ids.0", "zabbix_host.host_test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccZabbixDataSourceHostConfig(strID, host string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_host_group" "host_group_test" {
+			name = "host group %s"
+		}
+
+		resource "zabbix_template_group" "template_group_test" {
+			name = "template group %s"
+		}
+
+		resource "zabbix_template" "template_test" {
+			host = "template_%s"
+			groups = [zabbix_template_group.template_group_test.name]
+		}
+
+		resource "zabbix_host" "host_test" {
+			host = "%s"
+			name = "Host %s"
+			interfaces {
+				ip = "127.0.0.1"
+				main = true
+			}
+			groups = [zabbix_host_group.host_group_test.name]
+			templates = [zabbix_template.template_test.host]
+		}
+
+		data "zabbix_host" "by_host" {
+			host = zabbix_host.host_test.host
+		}
+
+		data "zabbix_host" "by_name" {
+			name = zabbix_host.host_test.name
+		}
+
+		data "zabbix_hosts" "by_group" {
+			groups = [zabbix_host_group.host_group_test.name]
+			depends_on = [zabbix_host.host_test]
+		}
+	`, strID, strID, strID, host, strID)
+}
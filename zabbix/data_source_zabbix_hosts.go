@@ -0,0 +1,72 @@
+package zabbix
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceZabbixHosts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZabbixHostsRead,
+		Schema: mergeSchemas(schemaHostFilter(), map[string]*schema.Schema{
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "IDs of the hosts matching the filters.",
+			},
+			"hosts": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: mergeSchemas(schemaHostData(), map[string]*schema.Schema{
+						"host": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					}),
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceZabbixHostsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	hosts, err := getHostsData(d, api, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].Host < hosts[j].Host })
+
+	ids := make([]string, len(hosts))
+	terraformHosts := make([]interface{}, len(hosts))
+	for i, host := range hosts {
+		ids[i] = host.HostID
+		terraformHosts[i] = flattenHostData(host)
+	}
+
+	d.SetId(createDataSourceListID(ids))
+	d.Set("ids", ids)
+	d.Set("hosts", terraformHosts)
+
+	log.Printf("[DEBUG] Found %d hosts\n", len(hosts))
+	return nil
+}
+
+// createDataSourceListID returns a stable ID for a data source returning the objects ids
+func createDataSourceListID(ids []string) string {
+	return strconv.Itoa(schema.HashString(strings.Join(ids, ",")))
+}
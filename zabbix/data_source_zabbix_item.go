@@ -0,0 +1,234 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// itemData represent the Zabbix item object returned by item.get
+type itemData struct {
+	itemObject
+	Status     string    `json:"status"`
+	TemplateID string    `json:"templateid"`
+	Tags       []tagData `json:"tags"`
+}
+
+func dataSourceZabbixItem() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZabbixItemRead,
+		Schema: mergeSchemas(schemaItemFilter(), map[string]*schema.Schema{
+			"key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact key of the item.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact name of the item.",
+			},
+			"host_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the host or template of the item.",
+			},
+		}, schemaItemData()),
+	}
+}
+
+// schemaItemFilter returns the arguments used to look up items
+func schemaItemFilter() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host_id": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "ID of the host or template of the item.",
+		},
+		"host": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Technical name of the host or template of the item.",
+		},
+		"key": &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"key_search"},
+			Description:   "Exact key of the item.",
+		},
+		"key_search": &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"key"},
+			Description:   "Pattern matched against the key of the item, * is a wildcard.",
+		},
+		"name": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Exact name of the item.",
+		},
+		"inherited": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "If true, only return items inherited from a template, if false only return items defined on the host or template itself.",
+		},
+		"tag": schemaTagFilter(),
+	}
+}
+
+// schemaItemData returns the attributes exported for an item
+func schemaItemData() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"item_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"template_id": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the parent template item, 0 when the item is not inherited.",
+		},
+		"interface_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"type": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"value_type": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"delay": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"history": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"trends": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"description": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"status": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"valuemap_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"tags": schemaTagsData(),
+	}
+}
+
+func dataSourceZabbixItemRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	items, err := getItemsData(d, api, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if len(items) != 1 {
+		return diag.Errorf("Expected one item matching the filters and got %d", len(items))
+	}
+
+	item := items[0]
+	d.SetId(item.ItemID)
+	for key, value := range flattenItemData(item) {
+		d.Set(key, value)
+	}
+
+	log.Printf("[DEBUG] Found item %s with id %s\n", item.Key, item.ItemID)
+	return nil
+}
+
+// getItemsData returns the items matching the filters of schemaItemFilter
+func getItemsData(d *schema.ResourceData, api *zabbix.API, zabbixVersion string) ([]itemData, error) {
+	params := zabbix.Params{
+		"output": "extend",
+	}
+	taggedItems := isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.4.0")
+	if taggedItems {
+		params["selectTags"] = "extend"
+	}
+
+	if v, ok := d.GetOk("host_id"); ok {
+		params["hostids"] = v.(string)
+	}
+	if v, ok := d.GetOk("host"); ok {
+		params["host"] = v.(string)
+	}
+
+	filter := map[string]interface{}{}
+	if v, ok := d.GetOk("key"); ok {
+		filter["key_"] = v.(string)
+	}
+	if v, ok := d.GetOk("name"); ok {
+		filter["name"] = v.(string)
+	}
+	if len(filter) > 0 {
+		params["filter"] = filter
+	}
+
+	if v, ok := d.GetOk("key_search"); ok {
+		params["search"] = map[string]interface{}{
+			"key_": v.(string),
+		}
+		params["searchWildcardsEnabled"] = strings.Contains(v.(string), "*")
+	}
+	if v, ok := d.GetOkExists("inherited"); ok {
+		params["inherited"] = v.(bool)
+	}
+
+	if v, ok := d.GetOk("tag"); ok {
+		if !taggedItems {
+			return nil, fmt.Errorf("Looking up items by tags requires Zabbix 5.4 or higher, server version is %s", zabbixVersion)
+		}
+		params["tags"] = createTagFilter(v.(*schema.Set))
+	}
+
+	var items []itemData
+	err := api.CallWithErrorParse("item.get", params, &items)
+	return items, err
+}
+
+// flattenItemData returns the attributes of schemaItemData for item
+func flattenItemData(item itemData) map[string]interface{} {
+	status := 0
+	if item.Status == "1" {
+		status = 1
+	}
+	return map[string]interface{}{
+		"item_id":      item.ItemID,
+		"host_id":      item.HostID,
+		"template_id":  item.TemplateID,
+		"interface_id": item.InterfaceID,
+		"key":          item.Key,
+		"name":         item.Name,
+		"type":         int(item.Type),
+		"value_type":   int(item.ValueType),
+		"delay":        item.Delay,
+		"history":      item.History,
+		"trends":       item.Trends,
+		"description":  item.Description,
+		"status":       status,
+		"valuemap_id":  item.ValueMapID,
+		"tags":         flattenTagsData(item.Tags),
+	}
+}
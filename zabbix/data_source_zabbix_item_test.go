@@ -0,0 +1,79 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccZabbixDataSourceItem_Basic(t *testing.T) {
+	strID := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixDataSourceItemConfig(strID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.zabbix_item.by_key", "id", "zabbix_item.first", "id"),
+					resource.TestCheckResourceAttr("data.zabbix_item.by_key", "name", "first_"+strID),
+					resource.TestCheckResourceAttr("data.zabbix_item.by_key", "type", "2"),
+					resource.TestCheckResourceAttr("data.zabbix_item.by_key", "value_type", "3"),
+					resource.TestCheckResourceAttr("data.zabbix_items.by_search", "items.#", "2"),
+					resource.TestCheckResourceAttr("data.zabbix_items.by_search", "items.0.key", "datasource.first"),
+					resource.TestCheckResourceAttr("data.zabbix_items.by_search", "items.1.key", "datasource.second"),
+					resource.TestCheckResourceAttr("data.zabbix_items.by_host", "ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccZabbixDataSourceItemConfig(strID string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_template_group" "host_group_test" {
+			name = "host_group_%s"
+		}
+
+		resource "zabbix_template" "template_test" {
+			host = "template_%s"
+			groups = [zabbix_template_group.host_group_test.name]
+		}
+
+		resource "zabbix_item" "first" {
+			name = "first_%s"
+			key = "datasource.first"
+			type = 2
+			value_type = 3
+			host_id = zabbix_template.template_test.id
+		}
+
+		resource "zabbix_item" "second" {
+			name = "second_%s"
+			key = "datasource.second"
+			type = 2
+			value_type = 3
+			host_id = zabbix_template.template_test.id
+		}
+
+		data "zabbix_item" "by_key" {
+			host_id = zabbix_template.template_test.id
+			key = zabbix_item.first.key
+		}
+
+		data "zabbix_items" "by_search" {
+			host_id = zabbix_template.template_test.id
+			key_search = "datasource.*"
+			depends_on = [zabbix_item.first, zabbix_item.second]
+		}
+
+		data "zabbix_items" "by_host" {
+			host = zabbix_template.template_test.host
+			inherited = false
+			depends_on = [zabbix_item.first, zabbix_item.second]
+		}
+	`, strID, strID, strID, strID)
+}
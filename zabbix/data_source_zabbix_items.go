@@ -0,0 +1,74 @@
+package zabbix
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceZabbixItems() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZabbixItemsRead,
+		Schema: mergeSchemas(schemaItemFilter(), map[string]*schema.Schema{
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "IDs of the items matching the filters.",
+			},
+			"items": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: mergeSchemas(schemaItemData(), map[string]*schema.Schema{
+						"host_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					}),
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceZabbixItemsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	items, err := getItemsData(d, api, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].HostID != items[j].HostID {
+			return items[i].HostID < items[j].HostID
+		}
+		return items[i].Key < items[j].Key
+	})
+
+	ids := make([]string, len(items))
+	terraformItems := make([]interface{}, len(items))
+	for i, item := range items {
+		ids[i] = item.ItemID
+		terraformItems[i] = flattenItemData(item)
+	}
+
+	d.SetId(createDataSourceListID(ids))
+	d.Set("ids", ids)
+	d.Set("items", terraformItems)
+
+	log.Printf("[DEBUG] Found %d items\n", len(items))
+	return nil
+}
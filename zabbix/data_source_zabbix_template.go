@@ -0,0 +1,186 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// templateData represent the Zabbix template object returned by template.get
+// https://www.zabbix.com/documentation/current/manual/api/reference/template/object
+type templateData struct {
+	TemplateID      string             `json:"templateid"`
+	Host            string             `json:"host"`
+	Name            string             `json:"name"`
+	Description     string             `json:"description"`
+	ParentTemplates []templateRef      `json:"parentTemplates"`
+	Groups          []zabbix.HostGroup `json:"groups"`
+	TemplateGroups  []zabbix.HostGroup `json:"templategroups"`
+	Macros          []userMacroData    `json:"macros"`
+	Tags            []tagData          `json:"tags"`
+}
+
+func dataSourceZabbixTemplate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZabbixTemplateRead,
+		Schema: mergeSchemas(schemaTemplateFilter(), map[string]*schema.Schema{
+			"host": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Technical name of the template.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Visible name of the template.",
+			},
+		}, schemaTemplateData()),
+	}
+}
+
+// schemaTemplateFilter returns the arguments used to look up templates
+func schemaTemplateFilter() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Technical name of the template.",
+		},
+		"name": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Visible name of the template.",
+		},
+		"groups": &schema.Schema{
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "Names of the groups, the template must belong to at least one of them.",
+		},
+		"tag": schemaTagFilter(),
+	}
+}
+
+// schemaTemplateData returns the attributes exported for a template
+func schemaTemplateData() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"template_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"description": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"group_ids": &schema.Schema{
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+		},
+		"template_ids": &schema.Schema{
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+		},
+		"templates": &schema.Schema{
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Computed:    true,
+			Description: "Technical names of the linked templates.",
+		},
+		"macros": schemaMacrosData(),
+		"tags":   schemaTagsData(),
+	}
+}
+
+func dataSourceZabbixTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	templates, err := getTemplatesData(d, api, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if len(templates) != 1 {
+		return diag.Errorf("Expected one template matching the filters and got %d", len(templates))
+	}
+
+	template := templates[0]
+	d.SetId(template.TemplateID)
+	for key, value := range flattenTemplateData(template) {
+		d.Set(key, value)
+	}
+
+	log.Printf("[DEBUG] Found template %s with id %s\n", template.Host, template.TemplateID)
+	return nil
+}
+
+// getTemplatesData returns the templates matching the filters of schemaTemplateFilter
+func getTemplatesData(d *schema.ResourceData, api *zabbix.API, zabbixVersion string) ([]templateData, error) {
+	params := zabbix.Params{
+		"output":                "extend",
+		"selectParentTemplates": []string{"templateid", "host", "name"},
+		"selectMacros":          "extend",
+	}
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.2.0") {
+		params["selectTemplateGroups"] = []string{"groupid", "name"}
+	} else {
+		params["selectGroups"] = []string{"groupid", "name"}
+	}
+	taggedTemplates := isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.4.0")
+	if taggedTemplates {
+		params["selectTags"] = "extend"
+	}
+
+	filter := map[string]interface{}{}
+	if v, ok := d.GetOk("host"); ok {
+		filter["host"] = v.(string)
+	}
+	if v, ok := d.GetOk("name"); ok {
+		filter["name"] = v.(string)
+	}
+	if len(filter) > 0 {
+		params["filter"] = filter
+	}
+
+	if v, ok := d.GetOk("groups"); ok {
+		groupIDs, err := getGroupIDsByName(api, getTemplateGroupAPI(zabbixVersion)+".get", v.(*schema.Set))
+		if err != nil {
+			return nil, err
+		}
+		params["groupids"] = groupIDs
+	}
+
+	if v, ok := d.GetOk("tag"); ok {
+		if !taggedTemplates {
+			return nil, fmt.Errorf("Looking up templates by tags requires Zabbix 5.4 or higher, server version is %s", zabbixVersion)
+		}
+		params["tags"] = createTagFilter(v.(*schema.Set))
+	}
+
+	var templates []templateData
+	err := api.CallWithErrorParse("template.get", params, &templates)
+	return templates, err
+}
+
+// flattenTemplateData returns the attributes of schemaTemplateData for template
+func flattenTemplateData(template templateData) map[string]interface{} {
+	templateIDs, templateNames := flattenTemplateRefs(template.ParentTemplates)
+	return map[string]interface{}{
+		"template_id":  template.TemplateID,
+		"host":         template.Host,
+		"name":         template.Name,
+		"description":  template.Description,
+		"group_ids":    flattenGroupIDs(append(template.Groups, template.TemplateGroups...)),
+		"template_ids": templateIDs,
+		"templates":    templateNames,
+		"macros":       flattenMacrosData(template.Macros),
+		"tags":         flattenTagsData(template.Tags),
+	}
+}
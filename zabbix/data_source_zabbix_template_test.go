@@ -0,0 +1,61 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccZabbixDataSourceTemplate_Basic(t *testing.T) {
+	strID := acctest.RandString(5)
+	template := fmt.Sprintf("template_%s", strID)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixDataSourceTemplateConfig(strID, template),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.zabbix_template.by_host", "id", "zabbix_template.template_test", "id"),
+					resource.TestCheckResourceAttr("data.zabbix_template.by_host", "description", "data source test"),
+					resource.TestCheckResourceAttr("data.zabbix_template.by_host", "macros.#", "1"),
+					resource.TestCheckResourceAttr("data.zabbix_template.by_host", "macros.0.name", "TEST_MACRO"),
+					resource.TestCheckResourceAttr("data.zabbix_template.by_host", "macros.0.value", "42"),
+					resource.TestCheckResourceAttr("data.zabbix_templates.by_group", "templates.#", "1"),
+					resource.TestCheckResourceAttr("data.zabbix_templates.by_group", "templates.0.host", template),
+					resource.TestCheckResourceAttrPair("data.zabbix_templates.by_group", "ids.0", "zabbix_template.template_test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccZabbixDataSourceTemplateConfig(strID, template string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_template_group" "host_group_test" {
+			name = "host group %s"
+		}
+
+		resource "zabbix_template" "template_test" {
+			host = "%s"
+			description = "data source test"
+			groups = [zabbix_template_group.host_group_test.name]
+			macro {
+				name = "TEST_MACRO"
+				value = "42"
+			}
+		}
+
+		data "zabbix_template" "by_host" {
+			host = zabbix_template.template_test.host
+		}
+
+		data "zabbix_templates" "by_group" {
+			groups = [zabbix_template_group.host_group_test.name]
+			depends_on = [zabbix_template.template_test]
+		}
+	`, strID, template)
+}
@@ -0,0 +1,65 @@
+package zabbix
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceZabbixTemplates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZabbixTemplatesRead,
+		Schema: mergeSchemas(schemaTemplateFilter(), map[string]*schema.Schema{
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "IDs of the templates matching the filters.",
+			},
+			"templates": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: mergeSchemas(schemaTemplateData(), map[string]*schema.Schema{
+						"host": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					}),
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceZabbixTemplatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	templates, err := getTemplatesData(d, api, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Host < templates[j].Host })
+
+	ids := make([]string, len(templates))
+	terraformTemplates := make([]interface{}, len(templates))
+	for i, template := range templates {
+		ids[i] = template.TemplateID
+		terraformTemplates[i] = flattenTemplateData(template)
+	}
+
+	d.SetId(createDataSourceListID(ids))
+	d.Set("ids", ids)
+	d.Set("templates", terraformTemplates)
+
+	log.Printf("[DEBUG] Found %d templates\n", len(templates))
+	return nil
+}
@@ -0,0 +1,206 @@
+package zabbix
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// triggerData represent the Zabbix trigger object returned by trigger.get
+type triggerData struct {
+	zabbix.Trigger
+	TemplateID string    `json:"templateid"`
+	Tags       []tagData `json:"tags"`
+}
+
+func dataSourceZabbixTrigger() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZabbixTriggerRead,
+		Schema: mergeSchemas(schemaTriggerFilter(), map[string]*schema.Schema{
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Exact name of the trigger.",
+			},
+		}, schemaTriggerData()),
+	}
+}
+
+// schemaTriggerFilter returns the arguments used to look up triggers
+func schemaTriggerFilter() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host_id": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "ID of the host or template of the trigger.",
+		},
+		"host": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Technical name of the host or template of the trigger.",
+		},
+		"description": &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"description_search"},
+			Description:   "Exact name of the trigger.",
+		},
+		"description_search": &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"description"},
+			Description:   "Pattern matched against the name of the trigger, * is a wildcard.",
+		},
+		"inherited": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "If true, only return triggers inherited from a template, if false only return triggers defined on the host or template itself.",
+		},
+		"tag": schemaTagFilter(),
+	}
+}
+
+// schemaTriggerData returns the attributes exported for a trigger
+func schemaTriggerData() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"trigger_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"template_id": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the parent template trigger, 0 when the trigger is not inherited.",
+		},
+		"expression": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Expression of the trigger, in the format of the zabbix_trigger resource.",
+		},
+		"comment": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"priority": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"status": &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"dependencies": &schema.Schema{
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+		},
+		"host_ids": &schema.Schema{
+			Type:     schema.TypeList,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+		},
+		"tags": schemaTagsData(),
+	}
+}
+
+func dataSourceZabbixTriggerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
+	api := client.api
+
+	triggers, err := getTriggersData(d, api)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if len(triggers) != 1 {
+		return diag.Errorf("Expected one trigger matching the filters and got %d", len(triggers))
+	}
+
+	trigger := triggers[0]
+	terraformTrigger, err := flattenTriggerData(trigger, api, client.cache)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	d.SetId(trigger.TriggerID)
+	for key, value := range terraformTrigger {
+		d.Set(key, value)
+	}
+
+	log.Printf("[DEBUG] Found trigger %s with id %s\n", trigger.Description, trigger.TriggerID)
+	return nil
+}
+
+// getTriggersData returns the triggers matching the filters of schemaTriggerFilter
+func getTriggersData(d *schema.ResourceData, api *zabbix.API) ([]triggerData, error) {
+	params := zabbix.Params{
+		"output":             "extend",
+		"selectDependencies": []string{"triggerid"},
+		"selectFunctions":    "extend",
+		"selectHosts":        []string{"hostid", "host"},
+		"selectTags":         "extend",
+	}
+
+	if v, ok := d.GetOk("host_id"); ok {
+		params["hostids"] = v.(string)
+	}
+	if v, ok := d.GetOk("host"); ok {
+		params["host"] = v.(string)
+	}
+	if v, ok := d.GetOk("description"); ok {
+		params["filter"] = map[string]interface{}{
+			"description": v.(string),
+		}
+	}
+	if v, ok := d.GetOk("description_search"); ok {
+		params["search"] = map[string]interface{}{
+			"description": v.(string),
+		}
+		params["searchWildcardsEnabled"] = strings.Contains(v.(string), "*")
+	}
+	if v, ok := d.GetOkExists("inherited"); ok {
+		params["inherited"] = v.(bool)
+	}
+	if v, ok := d.GetOk("tag"); ok {
+		params["tags"] = createTagFilter(v.(*schema.Set))
+	}
+
+	var triggers []triggerData
+	err := api.CallWithErrorParse("trigger.get", params, &triggers)
+	return triggers, err
+}
+
+// flattenTriggerData returns the attributes of schemaTriggerData for trigger,
+// the expression is expanded the same way as in the zabbix_trigger resource
+func flattenTriggerData(trigger triggerData, api *zabbix.API, cache *lookupCache) (map[string]interface{}, error) {
+	err := getTriggerExpression(&trigger.Trigger, api, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies := make([]string, len(trigger.Dependencies))
+	for i, dependency := range trigger.Dependencies {
+		dependencies[i] = dependency.TriggerID
+	}
+	hostIDs := make([]string, len(trigger.ParentHosts))
+	for i, host := range trigger.ParentHosts {
+		hostIDs[i] = host.HostID
+	}
+
+	return map[string]interface{}{
+		"trigger_id":   trigger.TriggerID,
+		"template_id":  trigger.TemplateID,
+		"description":  trigger.Description,
+		"expression":   trigger.Expression,
+		"comment":      trigger.Comments,
+		"priority":     int(trigger.Priority),
+		"status":       int(trigger.Status),
+		"dependencies": dependencies,
+		"host_ids":     hostIDs,
+		"tags":         flattenTagsData(trigger.Tags),
+	}, nil
+}
@@ -0,0 +1,78 @@
+package zabbix
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccZabbixDataSourceTrigger_Basic(t *testing.T) {
+	strID := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixDataSourceTriggerConfig(strID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.zabbix_trigger.by_description", "id", "zabbix_trigger.trigger_test", "id"),
+					resource.TestCheckResourceAttrPair("data.zabbix_trigger.by_description", "expression", "zabbix_trigger.trigger_test", "expression"),
+					resource.TestCheckResourceAttr("data.zabbix_trigger.by_description", "priority", "5"),
+					resource.TestCheckResourceAttr("data.zabbix_trigger.by_description", "comment", "trigger_comment"),
+					resource.TestCheckResourceAttr("data.zabbix_triggers.by_host", "triggers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.zabbix_triggers.by_host", "ids.0", "zabbix_trigger.trigger_test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccZabbixDataSourceTriggerConfig(strID string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_template_group" "host_group_test" {
+			name = "host_group_%s"
+		}
+
+		resource "zabbix_template" "template_test" {
+			host = "template_%s"
+			groups = [zabbix_template_group.host_group_test.name]
+		}
+
+		resource "zabbix_item" "item_test" {
+			name = "name_%s"
+			key = "lili.lala"
+			type = 2
+			host_id = zabbix_template.template_test.id
+		}
+
+		resource "zabbix_trigger" "trigger_test" {
+			description = "trigger_%s"
+			expression = "{${zabbix_template.template_test.host}:${zabbix_item.item_test.key}.last()}=0"
+			comment = "trigger_comment"
+			priority = 5
+		}
+
+		data "zabbix_trigger" "by_description" {
+			host_id = zabbix_template.template_test.id
+			description = zabbix_trigger.trigger_test.description
+		}
+
+		data "zabbix_triggers" "by_host" {
+			host = zabbix_template.template_test.host
+			depends_on = [zabbix_trigger.trigger_test]
+		}
+	`, strID, strID, strID, strID)
+}
+
+func TestLessID(t *testing.T) {
+	ids := []string{"100", "9", "13", "10"}
+	sort.Slice(ids, func(i, j int) bool { return lessID(ids[i], ids[j]) })
+	if expected := []string{"9", "10", "13", "100"}; !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("expected the IDs sorted numerically %v, got %v", expected, ids)
+	}
+}
@@ -0,0 +1,76 @@
+package zabbix
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceZabbixTriggers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZabbixTriggersRead,
+		Schema: mergeSchemas(schemaTriggerFilter(), map[string]*schema.Schema{
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "IDs of the triggers matching the filters.",
+			},
+			"triggers": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: mergeSchemas(schemaTriggerData(), map[string]*schema.Schema{
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					}),
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceZabbixTriggersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
+	api := client.api
+
+	triggers, err := getTriggersData(d, api)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	sort.Slice(triggers, func(i, j int) bool { return lessID(triggers[i].TriggerID, triggers[j].TriggerID) })
+
+	ids := make([]string, len(triggers))
+	terraformTriggers := make([]interface{}, len(triggers))
+	for i, trigger := range triggers {
+		ids[i] = trigger.TriggerID
+		terraformTriggers[i], err = flattenTriggerData(trigger, api, client.cache)
+		if err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	d.SetId(createDataSourceListID(ids))
+	d.Set("ids", ids)
+	d.Set("triggers", terraformTriggers)
+
+	log.Printf("[DEBUG] Found %d triggers\n", len(triggers))
+	return nil
+}
+
+// lessID compares the numeric IDs of Zabbix objects, "9" being before "10"
+func lessID(a, b string) bool {
+	i, errA := strconv.ParseUint(a, 10, 64)
+	j, errB := strconv.ParseUint(b, 10, 64)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return i < j
+}
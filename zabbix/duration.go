@@ -0,0 +1,145 @@
+package zabbix
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// durationRegexp matches a number with an optional time suffix
+var durationRegexp = regexp.MustCompile(`^([0-9]+)([smhdw]?)$`)
+
+var durationUnits = map[string]int64{
+	"s": 1,
+	"m": 60,
+	"h": 60 * 60,
+	"d": 24 * 60 * 60,
+	"w": 7 * 24 * 60 * 60,
+}
+
+// macroRegexp matches a user macro with an optional context or an LLD macro
+var macroRegexp = regexp.MustCompile(`^(\{\$[A-Z0-9_.]+(:.*)?\}|\{#[A-Z0-9_.]+\})$`)
+
+// periodRegexp matches the period of a flexible interval, "1-5,09:00-18:00"
+var periodRegexp = regexp.MustCompile(`^[1-7](-[1-7])?,([01]?[0-9]|2[0-4]):[0-5][0-9]-([01]?[0-9]|2[0-4]):[0-5][0-9]$`)
+
+// schedulingRegexp matches a scheduling interval, "wd1-5h9" or "h9-17m/30"
+var schedulingRegexp = regexp.MustCompile(`^((md|wd|h|m|s)([0-9]+(-[0-9]+)?)?(/[0-9]+)?(,([0-9]+(-[0-9]+)?)?(/[0-9]+)?)*)+$`)
+
+// parseDuration returns the number of seconds of a Zabbix time unit, a number
+// without suffix is a number of defaultUnit
+func parseDuration(v, defaultUnit string) (int64, bool) {
+	match := durationRegexp.FindStringSubmatch(v)
+	if match == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	unit := match[2]
+	if unit == "" {
+		unit = defaultUnit
+	}
+	return n * durationUnits[unit], true
+}
+
+func isMacro(v string) bool {
+	return macroRegexp.MatchString(v)
+}
+
+// validateDelay validates an update interval followed by flexible and
+// scheduling intervals, "1m;50s/1-5,09:00-18:00;wd1-5h9"
+func validateDelay(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if v == "" {
+		return
+	}
+
+	intervals := strings.Split(v, ";")
+	if _, ok := parseDuration(intervals[0], "s"); !ok && !isMacro(intervals[0]) {
+		errs = append(errs, fmt.Errorf("%q, must start with an update interval with an optional s, m, h, d or w suffix or a macro, got %s", key, v))
+	}
+	for _, interval := range intervals[1:] {
+		if !isFlexibleInterval(interval) && !schedulingRegexp.MatchString(interval) && !isMacro(interval) {
+			errs = append(errs, fmt.Errorf("%q, %s is neither a flexible interval like 50s/1-5,09:00-18:00 nor a scheduling interval like wd1-5h9", key, interval))
+		}
+	}
+	return
+}
+
+func isFlexibleInterval(interval string) bool {
+	parts := strings.Split(interval, "/")
+	if len(parts) != 2 {
+		return false
+	}
+	if _, ok := parseDuration(parts[0], "s"); !ok && !isMacro(parts[0]) {
+		return false
+	}
+	return periodRegexp.MatchString(parts[1]) || isMacro(parts[1])
+}
+
+// hasDelaySuffix returns true when the update interval or the interval of a
+// flexible interval of delay has a time suffix
+func hasDelaySuffix(delay string) bool {
+	for i, interval := range strings.Split(delay, ";") {
+		if i > 0 {
+			if !isFlexibleInterval(interval) {
+				continue
+			}
+			interval = strings.Split(interval, "/")[0]
+		}
+		if match := durationRegexp.FindStringSubmatch(interval); match != nil && match[2] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// validateStoragePeriod validates history and trends storage periods, a number
+// without suffix is a number of days
+func validateStoragePeriod(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if _, ok := parseDuration(v, "d"); v != "" && !ok && !isMacro(v) {
+		errs = append(errs, fmt.Errorf("%q, must be a number of days, a number with a s, m, h, d or w suffix or a macro, got %s", key, v))
+	}
+	return
+}
+
+// normalizeDelay converts the update interval and the intervals of the flexible
+// intervals to seconds, so that "1h", "60m" and "3600" are equal
+func normalizeDelay(v string) string {
+	intervals := strings.Split(v, ";")
+	for i, interval := range intervals {
+		if seconds, ok := parseDuration(interval, "s"); ok && i == 0 {
+			intervals[i] = strconv.FormatInt(seconds, 10)
+		} else if i > 0 && isFlexibleInterval(interval) {
+			parts := strings.Split(interval, "/")
+			if seconds, ok := parseDuration(parts[0], "s"); ok {
+				intervals[i] = fmt.Sprintf("%d/%s", seconds, parts[1])
+			}
+		}
+	}
+	return strings.Join(intervals, ";")
+}
+
+// normalizeStoragePeriod converts a storage period to seconds
+func normalizeStoragePeriod(v string) string {
+	if seconds, ok := parseDuration(v, "d"); ok {
+		return strconv.FormatInt(seconds, 10)
+	}
+	return v
+}
+
+func suppressEquivalentDelays(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeDelay(old) == normalizeDelay(new)
+}
+
+// suppressEquivalentStoragePeriods suppresses the diff between "90", "90d" and
+// "2160h", so that switching the server version doesn't change the plan
+func suppressEquivalentStoragePeriods(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeStoragePeriod(old) == normalizeStoragePeriod(new)
+}
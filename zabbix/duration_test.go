@@ -0,0 +1,86 @@
+package zabbix
+
+import (
+	"testing"
+)
+
+func TestValidateDelay(t *testing.T) {
+	valid := []string{
+		"30",
+		"1m",
+		"{$INTERVAL}",
+		"{$INTERVAL:\"agent\"}",
+		"{#INTERVAL}",
+		"1h;50s/1-5,09:00-18:00",
+		"0;wd1-5h9",
+		"10m;h9-17m/30;md1,15h8",
+		"{$INTERVAL};{$INTERVAL}/{$PERIOD}",
+	}
+	for _, v := range valid {
+		if _, errs := validateDelay(v, "delay"); len(errs) > 0 {
+			t.Errorf("expected %s to be valid, got %v", v, errs)
+		}
+	}
+
+	invalid := []string{
+		"1y",
+		"one minute",
+		"1m;50s/8,09:00-18:00",
+		"1m;xyz",
+	}
+	for _, v := range invalid {
+		if _, errs := validateDelay(v, "delay"); len(errs) == 0 {
+			t.Errorf("expected %s to be invalid", v)
+		}
+	}
+}
+
+func TestHasDelaySuffix(t *testing.T) {
+	cases := map[string]bool{
+		"60":                       false,
+		"{$INTERVAL}":              false,
+		"60;wd1-5h9":               false,
+		"60;50/1-5,09:00-18:00":    false,
+		"1m":                       true,
+		"60;1m/1-5,09:00-18:00":    true,
+		"{$INTERVAL};5m/{$PERIOD}": true,
+	}
+	for delay, expected := range cases {
+		if hasDelaySuffix(delay) != expected {
+			t.Errorf("expected the time suffix of %s to be %t", delay, expected)
+		}
+	}
+}
+
+func TestSuppressEquivalentDurations(t *testing.T) {
+	equivalentDelays := [][2]string{
+		{"1h", "60m"},
+		{"3600", "1h"},
+		{"1h;50s/1-5,09:00-18:00", "3600s;50/1-5,09:00-18:00"},
+	}
+	for _, c := range equivalentDelays {
+		if !suppressEquivalentDelays("delay", c[0], c[1], nil) {
+			t.Errorf("expected %s and %s to be equivalent", c[0], c[1])
+		}
+	}
+	if suppressEquivalentDelays("delay", "1h", "1m", nil) || suppressEquivalentDelays("delay", "{$A}", "{$B}", nil) {
+		t.Error("expected different delays not to be equivalent")
+	}
+
+	equivalentPeriods := [][2]string{
+		{"90", "90d"},
+		{"7", "1w"},
+		{"1d", "24h"},
+	}
+	for _, c := range equivalentPeriods {
+		if !suppressEquivalentStoragePeriods("history", c[0], c[1], nil) {
+			t.Errorf("expected %s and %s to be equivalent", c[0], c[1])
+		}
+	}
+	if suppressEquivalentStoragePeriods("history", "90d", "30", nil) || suppressEquivalentStoragePeriods("history", "90", "90s", nil) {
+		t.Error("expected different storage periods not to be equivalent")
+	}
+	if _, errs := validateStoragePeriod("1y", "history"); len(errs) == 0 {
+		t.Error("expected 1y to be an invalid storage period")
+	}
+}
@@ -1,12 +1,16 @@
 package zabbix
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -18,12 +22,59 @@ func sqlError(err error) bool {
 	return false
 }
 
+// notFoundError is returned when the requested Zabbix object doesn't exist
+type notFoundError struct {
+	object string
+	id     string
+}
+
+func (e *notFoundError) Error() string {
+	return fmt.Sprintf("%s with id %s not found", e.object, e.id)
+}
+
+// isNotFoundError reports whether err means that the Zabbix object doesn't exist,
+// the client library returns an ExpectedOneResult of 0 in that case
+func isNotFoundError(err error) bool {
+	var notFound *notFoundError
+	if errors.As(err, &notFound) {
+		return true
+	}
+	var expectedOneResult *zabbix.ExpectedOneResult
+	return errors.As(err, &expectedOneResult) && *expectedOneResult == 0
+}
+
+// expectOneResult returns the error of a get of the object with the given id
+// that returned count results
+func expectOneResult(object, id string, count int) error {
+	switch count {
+	case 1:
+		return nil
+	case 0:
+		return &notFoundError{object: object, id: id}
+	default:
+		e := zabbix.ExpectedOneResult(count)
+		return &e
+	}
+}
+
+// removeFromStateIfNotFound removes the resource from the state when err is a
+// not found error, so that objects deleted outside of Terraform are recreated,
+// the other errors are returned
+func removeFromStateIfNotFound(d *schema.ResourceData, object string, err error) error {
+	if isNotFoundError(err) {
+		log.Printf("[WARN] %s with id %s not found, removing it from the state", object, d.Id())
+		d.SetId("")
+		return nil
+	}
+	return err
+}
+
 type deleteFunc func([]string) ([]interface{}, error)
 type createFunc func(interface{}, *zabbix.API) (string, error)
 type getParentFunc func(*zabbix.API, string) (string, error)
 
-func deleteRetry(id string, get getParentFunc, delete deleteFunc, api *zabbix.API) error {
-	return resource.Retry(time.Minute, func() *resource.RetryError {
+func deleteRetry(ctx context.Context, id string, get getParentFunc, delete deleteFunc, api *zabbix.API) error {
+	return resource.RetryContext(ctx, time.Minute, func() *resource.RetryError {
 		parentID, err := get(api, id)
 		if err != nil {
 			if sqlError(err) {
@@ -58,9 +109,9 @@ func deleteRetry(id string, get getParentFunc, delete deleteFunc, api *zabbix.AP
 	})
 }
 
-func createRetry(d *schema.ResourceData, meta interface{}, create createFunc, createArg interface{}, read schema.ReadFunc) error {
-	return resource.Retry(time.Minute, func() *resource.RetryError {
-		api := meta.(*zabbix.API)
+func createRetry(ctx context.Context, d *schema.ResourceData, meta interface{}, create createFunc, createArg interface{}, read schema.ReadContextFunc) diag.Diagnostics {
+	err := resource.RetryContext(ctx, time.Minute, func() *resource.RetryError {
+		api := meta.(*providerClient).api
 		id, err := create(createArg, api)
 		if err != nil {
 			if sqlError(err) {
@@ -71,12 +122,62 @@ func createRetry(d *schema.ResourceData, meta interface{}, create createFunc, cr
 		if d.Id() == "" {
 			d.SetId(id)
 		}
+		return nil
+	})
+	if err != nil {
+		return diagFromErr(err)
+	}
 
-		err = read(d, meta)
-		if err != nil {
-			return resource.NonRetryableError(err)
-		}
+	return read(ctx, d, meta)
+}
+
+// attributeError is an error caused by the value of an attribute, the
+// diagnostic of the error points to the attribute
+type attributeError struct {
+	attribute string
+	err       error
+}
 
+func (e *attributeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *attributeError) Unwrap() error {
+	return e.err
+}
+
+func attributeErrorf(attribute string, format string, a ...interface{}) error {
+	return &attributeError{attribute: attribute, err: fmt.Errorf(format, a...)}
+}
+
+// diagFromErr converts err to diagnostics, attribute errors get the path of
+// their attribute and Zabbix API errors are split into a summary and details
+func diagFromErr(err error) diag.Diagnostics {
+	if err == nil {
 		return nil
-	})
+	}
+
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  err.Error(),
+	}
+
+	var apiError *zabbix.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		diagnostic.Summary = "Zabbix request canceled"
+		diagnostic.Detail = err.Error()
+	case errors.Is(err, context.DeadlineExceeded):
+		diagnostic.Summary = "Zabbix request timed out"
+		diagnostic.Detail = err.Error()
+	case errors.As(err, &apiError):
+		diagnostic.Summary = fmt.Sprintf("Zabbix API error %d: %s", apiError.Code, apiError.Message)
+		diagnostic.Detail = apiError.Data
+	}
+
+	var attrError *attributeError
+	if errors.As(err, &attrError) {
+		diagnostic.AttributePath = cty.GetAttrPath(attrError.attribute)
+	}
+	return diag.Diagnostics{diagnostic}
 }
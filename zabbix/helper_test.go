@@ -0,0 +1,44 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestDiagFromErr(t *testing.T) {
+	if diags := diagFromErr(nil); diags != nil {
+		t.Fatalf("expected no diagnostics, got %#v", diags)
+	}
+
+	diags := diagFromErr(attributeErrorf("schedule", "SLA schedule period_from must be lower than period_to, got %d and %d", 10, 5))
+	if len(diags) != 1 || !diags[0].AttributePath.Equals(cty.GetAttrPath("schedule")) {
+		t.Fatalf("expected a diagnostic on schedule, got %#v", diags)
+	}
+
+	diags = diagFromErr(&zabbix.Error{Code: -32602, Message: "Invalid params.", Data: "Incorrect value for field \"name\"."})
+	if diags[0].Summary != "Zabbix API error -32602: Invalid params." || diags[0].Detail != "Incorrect value for field \"name\"." {
+		t.Fatalf("expected the API error in the summary and the details, got %#v", diags)
+	}
+
+	diags = diagFromErr(fmt.Errorf("Post \"https://zabbix/api_jsonrpc.php\": %w", context.Canceled))
+	if diags[0].Summary != "Zabbix request canceled" {
+		t.Fatalf("expected a canceled request, got %#v", diags)
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	if !isNotFoundError(expectOneResult("SLA", "1", 0)) {
+		t.Fatal("expected a not found error for no result")
+	}
+	notFound := zabbix.ExpectedOneResult(0)
+	if !isNotFoundError(&notFound) {
+		t.Fatal("expected a not found error for an ExpectedOneResult of 0")
+	}
+	if isNotFoundError(expectOneResult("SLA", "1", 2)) {
+		t.Fatal("expected two results not to be a not found error")
+	}
+}
@@ -0,0 +1,212 @@
+package zabbix
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// httpConfig is the configuration of the HTTP client of a Zabbix server
+type httpConfig struct {
+	tlsInsecure       bool
+	caFile            string
+	caPEM             string
+	clientCert        string
+	clientKey         string
+	proxyURL          string
+	headers           map[string]interface{}
+	basicAuthUser     string
+	basicAuthPassword string
+}
+
+// httpClientSchema returns the arguments of the HTTP client, the arguments of the
+// default server are also set by environment variables
+func httpClientSchema(withEnv bool) map[string]*schema.Schema {
+	envDefault := func(env string) schema.SchemaDefaultFunc {
+		if !withEnv {
+			return nil
+		}
+		return schema.EnvDefaultFunc(env, nil)
+	}
+
+	return map[string]*schema.Schema{
+		"tls_insecure": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			DefaultFunc: envDefault("ZABBIX_TLS_INSECURE"),
+		},
+		"ca_file": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: envDefault("ZABBIX_CA_FILE"),
+			Description: "Path of a PEM bundle of certificate authorities trusted in addition to the system ones.",
+		},
+		"ca_pem": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: envDefault("ZABBIX_CA_PEM"),
+			Description: "PEM bundle of certificate authorities trusted in addition to the system ones.",
+		},
+		"client_cert": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: envDefault("ZABBIX_CLIENT_CERT"),
+			Description: "PEM client certificate or path of the certificate file, for mutual TLS.",
+		},
+		"client_key": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			DefaultFunc: envDefault("ZABBIX_CLIENT_KEY"),
+			Description: "PEM private key of the client certificate or path of the key file.",
+		},
+		"proxy_url": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: envDefault("ZABBIX_PROXY_URL"),
+			Description: "URL of the HTTP proxy to the Zabbix frontend.",
+		},
+		"headers": &schema.Schema{
+			Type:        schema.TypeMap,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Optional:    true,
+			Description: "Additional HTTP headers sent with every request, for SSO gateways.",
+		},
+		"basic_auth_user": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: envDefault("ZABBIX_BASIC_AUTH_USER"),
+			Description: "User of the HTTP basic authentication of the frontend web server.",
+		},
+		"basic_auth_password": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			DefaultFunc: envDefault("ZABBIX_BASIC_AUTH_PASSWORD"),
+			Description: "Password of the HTTP basic authentication of the frontend web server.",
+		},
+	}
+}
+
+// getHTTPConfig reads the arguments of httpClientSchema with get
+func getHTTPConfig(get func(key string) interface{}) httpConfig {
+	return httpConfig{
+		tlsInsecure:       get("tls_insecure").(bool),
+		caFile:            get("ca_file").(string),
+		caPEM:             get("ca_pem").(string),
+		clientCert:        get("client_cert").(string),
+		clientKey:         get("client_key").(string),
+		proxyURL:          get("proxy_url").(string),
+		headers:           get("headers").(map[string]interface{}),
+		basicAuthUser:     get("basic_auth_user").(string),
+		basicAuthPassword: get("basic_auth_password").(string),
+	}
+}
+
+func newHTTPTransport(config httpConfig) (http.RoundTripper, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.tlsInsecure,
+	}
+
+	if config.caFile != "" || config.caPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if config.caFile != "" {
+			pem, err := os.ReadFile(config.caFile)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to read ca_file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("No certificate found in ca_file %s", config.caFile)
+			}
+		}
+		if config.caPEM != "" && !pool.AppendCertsFromPEM([]byte(config.caPEM)) {
+			return nil, fmt.Errorf("No certificate found in ca_pem")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.clientCert != "" || config.clientKey != "" {
+		cert, err := readPEM("client_cert", config.clientCert)
+		if err != nil {
+			return nil, err
+		}
+		key, err := readPEM("client_key", config.clientKey)
+		if err != nil {
+			return nil, err
+		}
+		certificate, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid client_cert or client_key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport := &http.Transport{
+		TLSClientConfig: tlsConfig,
+	}
+	if config.proxyURL != "" {
+		proxyURL, err := url.Parse(config.proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid proxy_url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(config.headers) == 0 && config.basicAuthUser == "" {
+		return transport, nil
+	}
+	headers := make(http.Header, len(config.headers))
+	for name, value := range config.headers {
+		headers.Set(name, value.(string))
+	}
+	return &headerTransport{
+		transport:         transport,
+		headers:           headers,
+		basicAuthUser:     config.basicAuthUser,
+		basicAuthPassword: config.basicAuthPassword,
+	}, nil
+}
+
+// readPEM returns a PEM value or the content of the file it is the path of
+func readPEM(key, value string) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf("client_cert and client_key must be set together")
+	}
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	content, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read %s: %w", key, err)
+	}
+	return content, nil
+}
+
+// headerTransport adds the headers and the basic authentication of the frontend
+// web server to the requests
+type headerTransport struct {
+	transport         http.RoundTripper
+	headers           http.Header
+	basicAuthUser     string
+	basicAuthPassword string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.headers {
+		req.Header[name] = values
+	}
+	if t.basicAuthUser != "" {
+		req.SetBasicAuth(t.basicAuthUser, t.basicAuthPassword)
+	}
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,66 @@
+package zabbix
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPTransportCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport, err := newHTTPTransport(httpConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&http.Client{Transport: transport}).Get(server.URL); err == nil {
+		t.Fatal("expected the certificate of the test server to be unknown")
+	}
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	transport, err = newHTTPTransport(httpConfig{caPEM: string(caPEM)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if _, err := newHTTPTransport(httpConfig{caPEM: "not a certificate"}); err == nil {
+		t.Fatal("expected an invalid ca_pem to fail")
+	}
+	if _, err := newHTTPTransport(httpConfig{clientCert: string(caPEM)}); err == nil {
+		t.Fatal("expected a client_cert without client_key to fail")
+	}
+}
+
+func TestHTTPTransportHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "frontend" || password != "secret" {
+			t.Errorf("expected the basic authentication of the frontend, got %s", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Gateway-Token") != "token" {
+			t.Errorf("expected the additional header, got %v", r.Header)
+		}
+	}))
+	defer server.Close()
+
+	transport, err := newHTTPTransport(httpConfig{
+		headers:           map[string]interface{}{"X-Gateway-Token": "token"},
+		basicAuthUser:     "frontend",
+		basicAuthPassword: "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+}
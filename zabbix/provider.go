@@ -1,10 +1,10 @@
 package zabbix
 
 import (
-	"crypto/tls"
+	"context"
 	"fmt"
-	"log"
 	"net/http"
+	"strings"
 
 	"github.com/claranet/go-zabbix-api"
 	"github.com/hashicorp/go-version"
@@ -31,30 +31,136 @@ func Provider() *schema.Provider {
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ZABBIX_SERVER_URL", nil),
 			},
-			"tls_insecure": &schema.Schema{
-				Type:        schema.TypeBool,
+			"audit_dir": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ZABBIX_AUDIT_DIR", nil),
+				Description: "Directory of the audit files, a JSON line is written for each request of a run of the provider.",
+			},
+			"server": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Additional Zabbix servers, selected by name with the server argument of the resources and data sources.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(string)
+								if v == "" || strings.Contains(v, ":") {
+									errs = append(errs, fmt.Errorf("%q, must be a non empty name without colon, got %q", key, v))
+								}
+								return
+							},
+						},
+						"url": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"user": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"password": &schema.Schema{
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"token": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "API token used instead of the user and password (Zabbix 5.4+).",
+						},
+					},
+				},
+			},
+			"max_concurrent_requests": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ZABBIX_MAX_CONCURRENT_REQUESTS", 0),
+				Description: "Maximum number of concurrent requests to the Zabbix API, 0 for no limit.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 {
+						errs = append(errs, fmt.Errorf("%q, must be 0 or greater, got %d", key, v))
+					}
+					return
+				},
+			},
+			"requests_per_second": &schema.Schema{
+				Type:        schema.TypeFloat,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("ZABBIX_TLS_INSECURE", nil),
+				DefaultFunc: schema.EnvDefaultFunc("ZABBIX_REQUESTS_PER_SECOND", 0),
+				Description: "Maximum number of requests per second to the Zabbix API, 0 for no limit.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(float64)
+					if v < 0 {
+						errs = append(errs, fmt.Errorf("%q, must be 0 or greater, got %g", key, v))
+					}
+					return
+				},
 			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"zabbix_server": dataSourceZabbixServer(),
+			"zabbix_server":               dataSourceZabbixServer(),
+			"zabbix_host":                 dataSourceZabbixHost(),
+			"zabbix_hosts":                dataSourceZabbixHosts(),
+			"zabbix_host_group":           dataSourceZabbixHostGroup(),
+			"zabbix_host_groups":          dataSourceZabbixHostGroups(),
+			"zabbix_template":             dataSourceZabbixTemplate(),
+			"zabbix_templates":            dataSourceZabbixTemplates(),
+			"zabbix_item":                 dataSourceZabbixItem(),
+			"zabbix_items":                dataSourceZabbixItems(),
+			"zabbix_trigger":              dataSourceZabbixTrigger(),
+			"zabbix_triggers":             dataSourceZabbixTriggers(),
+			"zabbix_configuration_export": dataSourceZabbixConfigurationExport(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"zabbix_host":              resourceZabbixHost(),
-			"zabbix_host_group":        resourceZabbixHostGroup(),
-			"zabbix_item":              resourceZabbixItem(),
-			"zabbix_trigger":           resourceZabbixTrigger(),
-			"zabbix_template":          resourceZabbixTemplate(),
-			"zabbix_template_link":     resourceZabbixTemplateLink(),
-			"zabbix_lld_rule":          resourceZabbixLLDRule(),
-			"zabbix_item_prototype":    resourceZabbixItemPrototype(),
-			"zabbix_trigger_prototype": resourceZabbixTriggerPrototype(),
+			"zabbix_host":                   resourceZabbixHost(),
+			"zabbix_hosts_bulk":             resourceZabbixHostsBulk(),
+			"zabbix_host_group":             resourceZabbixHostGroup(),
+			"zabbix_item":                   resourceZabbixItem(),
+			"zabbix_trigger":                resourceZabbixTrigger(),
+			"zabbix_template":               resourceZabbixTemplate(),
+			"zabbix_template_link":          resourceZabbixTemplateLink(),
+			"zabbix_template_group":         resourceZabbixTemplateGroup(),
+			"zabbix_lld_rule":               resourceZabbixLLDRule(),
+			"zabbix_item_prototype":         resourceZabbixItemPrototype(),
+			"zabbix_trigger_prototype":      resourceZabbixTriggerPrototype(),
+			"zabbix_network_discovery_rule": resourceZabbixNetworkDiscoveryRule(),
+			"zabbix_value_map":              resourceZabbixValueMap(),
+			"zabbix_global_macro":           resourceZabbixGlobalMacro(),
+			"zabbix_settings":               resourceZabbixSettings(),
+			"zabbix_configuration_import":   resourceZabbixConfigurationImport(),
+			"zabbix_service":                resourceZabbixService(),
+			"zabbix_sla":                    resourceZabbixSLA(),
+			"zabbix_correlation":            resourceZabbixCorrelation(),
+			"zabbix_script":                 resourceZabbixScript(),
+			"zabbix_autoregistration":       resourceZabbixAutoregistration(),
 		},
 	}
 
+	// the HTTP client of the default server and of each server block
+	for key, argument := range httpClientSchema(true) {
+		p.Schema[key] = argument
+	}
+	serverSchema := p.Schema["server"].Elem.(*schema.Resource).Schema
+	for key, argument := range httpClientSchema(false) {
+		serverSchema[key] = argument
+	}
+
+	// every resource and data source can be managed on one of the servers
+	for _, r := range p.ResourcesMap {
+		addServerArgument(r, true)
+	}
+	for _, r := range p.DataSourcesMap {
+		addServerArgument(r, false)
+	}
+
 	p.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
 		terraformVersion := p.TerraformVersion
 		if terraformVersion == "" {
@@ -69,46 +175,132 @@ func Provider() *schema.Provider {
 }
 
 func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
-	api := zabbix.NewAPI(d.Get("server_url").(string))
+	servers := make(map[string]*providerClient)
+
+	var audit *auditLog
+	if dir := d.Get("audit_dir").(string); dir != "" {
+		var err error
+		audit, err = newAuditLog(dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := newProviderClient(d, serverConfig{
+		url:      d.Get("server_url").(string),
+		user:     d.Get("user").(string),
+		password: d.Get("password").(string),
+		http:     getHTTPConfig(d.Get),
+	}, audit, terraformVersion)
+	if err != nil {
+		return nil, err
+	}
+	servers[""] = client
+
+	for _, s := range d.Get("server").([]interface{}) {
+		server := s.(map[string]interface{})
+		name := server["name"].(string)
+		if _, ok := servers[name]; ok {
+			return nil, fmt.Errorf("Server %s is configured more than once", name)
+		}
+		if server["token"].(string) == "" && server["user"].(string) == "" {
+			return nil, fmt.Errorf("Server %s requires a user and a password or a token", name)
+		}
+
+		client, err := newProviderClient(d, serverConfig{
+			url:      server["url"].(string),
+			user:     server["user"].(string),
+			password: server["password"].(string),
+			token:    server["token"].(string),
+			http:     getHTTPConfig(func(key string) interface{} { return server[key] }),
+		}, audit, terraformVersion)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to configure server %s: %w", name, err)
+		}
+		servers[name] = client
+	}
+
+	for _, client := range servers {
+		client.servers = servers
+	}
+	return servers[""], nil
+}
+
+// newProviderClient logs in to a Zabbix server, each server has its own
+// request limits, lookup cache and version
+func newProviderClient(d *schema.ResourceData, server serverConfig, audit *auditLog, terraformVersion string) (*providerClient, error) {
+	api := zabbix.NewAPI(server.url)
 
 	api.UserAgent = fmt.Sprintf("HashiCorp/1.0 Terraform/%s", terraformVersion)
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: d.Get("tls_insecure").(bool),
-			},
-		},
+	transport, err := newHTTPTransport(server.http)
+	if err != nil {
+		return nil, err
 	}
+	client := &http.Client{Transport: transport}
 
-	if logging.IsDebugOrHigher() {
-		client.Transport = logging.NewTransport("Zabbix", client.Transport)
+	if logging.IsDebugOrHigher() || audit != nil {
+		client.Transport = newRPCLogTransport(client.Transport, audit)
 	}
 
+	// the limits are shared by all the requests to the server
+	client.Transport = newLimitTransport(client.Transport, d.Get("max_concurrent_requests").(int), d.Get("requests_per_second").(float64))
+
 	api.SetClient(client)
 
-	if _, err := api.Login(d.Get("user").(string), d.Get("password").(string)); err != nil {
+	if server.token != "" {
+		// API tokens are sent as session IDs (Zabbix 5.4+)
+		api.Auth = server.token
+	} else if _, err := api.Login(server.user, server.password); err != nil {
 		return nil, err
 	}
 
-	return api, nil
+	return &providerClient{api: api, transport: client.Transport, cache: newLookupCache(), version: &serverVersion{}}, nil
 }
 
-func getZabbixServerVersion(meta interface{}) string {
-	api := meta.(*zabbix.API)
-	v, err := api.Version()
-	if err != nil {
-		log.Printf("[WARN] Failed to get Zabbix Server version: %v\n", err)
-		return ""
-	}
-	log.Printf("[DEBUG] Zabbix Server version is %s\n", v)
+// providerClient is the meta of the provider, the HTTP transport of the API is
+// kept to bind the requests of each operation to its context
+type providerClient struct {
+	api       *zabbix.API
+	transport http.RoundTripper
+	cache     *lookupCache
+	version   *serverVersion
+	// servers are the clients of the servers by name, "" is the default server
+	servers map[string]*providerClient
+}
+
+// contextTransport sends the requests with the context of a Terraform operation,
+// so that interrupting Terraform cancels the requests in flight
+type contextTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+}
 
-	return v
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(req.WithContext(t.ctx))
+}
+
+// withContext returns a copy of the client of the server of the resource, whose
+// requests are canceled with ctx
+func withContext(ctx context.Context, d resourceGetter, meta interface{}) interface{} {
+	client := selectServer(d, meta.(*providerClient))
+
+	api := *client.api
+	api.SetClient(&http.Client{Transport: &contextTransport{ctx: ctx, transport: client.transport}})
+	return &providerClient{api: &api, transport: client.transport, cache: client.cache, version: client.version, servers: client.servers}
 }
 
 func isZabbixServerVersion34OrHigher(zabbixVersion string) bool {
-	v1, _ := version.NewVersion(zabbixVersion)
-	v2, _ := version.NewVersion("3.4.0")
+	return isZabbixServerVersionGreaterOrEqual(zabbixVersion, "3.4.0")
+}
+
+// isZabbixServerVersionGreaterOrEqual returns false when zabbixVersion can't be parsed
+func isZabbixServerVersionGreaterOrEqual(zabbixVersion, minVersion string) bool {
+	v1, err := version.NewVersion(zabbixVersion)
+	if err != nil {
+		return false
+	}
+	v2, _ := version.NewVersion(minVersion)
 
 	return v1.GreaterThanOrEqual(v2)
 }
@@ -47,3 +47,12 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// testAccSkipIfZabbixVersionLower skips the test when the Zabbix server is older
+// than minVersion, it must be called after testAccPreCheck
+func testAccSkipIfZabbixVersionLower(t *testing.T, minVersion string) {
+	zabbixVersion := getZabbixServerVersion(testAccProvider.Meta())
+	if !isZabbixServerVersionGreaterOrEqual(zabbixVersion, minVersion) {
+		t.Skipf("Zabbix server %s is older than %s", zabbixVersion, minVersion)
+	}
+}
@@ -0,0 +1,147 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// autoregistrationID is the ID of the singleton zabbix_autoregistration resource
+const autoregistrationID = "autoregistration"
+
+// tlsAcceptPSK is the bit of the PSK encrypted connections in tls_accept
+const tlsAcceptPSK = 2
+
+var pskRegexp = regexp.MustCompile(`^([0-9a-fA-F]{2}){16,256}$`)
+
+// autoregistrationObject represent Zabbix autoregistration object, the PSK
+// identity and the PSK are write only
+// https://www.zabbix.com/documentation/current/manual/api/reference/autoregistration/object
+type autoregistrationObject struct {
+	TLSAccept      int    `json:"tls_accept,string"`
+	TLSPSKIdentity string `json:"tls_psk_identity,omitempty"`
+	TLSPSK         string `json:"tls_psk,omitempty"`
+}
+
+func resourceZabbixAutoregistration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixAutoregistrationCreate,
+		ReadContext:   resourceZabbixAutoregistrationRead,
+		UpdateContext: resourceZabbixAutoregistrationUpdate,
+		DeleteContext: resourceZabbixAutoregistrationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeDiffVersions("zabbix_autoregistration", "5.0.0"),
+		Schema: map[string]*schema.Schema{
+			"tls_accept": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "Accepted connections, 1 unencrypted, 2 PSK or 3 both.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 1 || v > 3 {
+						errs = append(errs, fmt.Errorf("%q, must be between 1 and 3 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"tls_psk_identity": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				RequiredWith: []string{"tls_psk"},
+				Description:  "PSK identity, required by the PSK encrypted connections.",
+			},
+			"tls_psk": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				Sensitive:    true,
+				RequiredWith: []string{"tls_psk_identity"},
+				Description:  "Pre-shared key of at least 32 hexadecimal digits, required by the PSK encrypted connections.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if !pskRegexp.MatchString(v) {
+						errs = append(errs, fmt.Errorf("%q, must be an even number of hexadecimal digits between 32 and 512, got %d characters", key, len(v)))
+					}
+					return
+				},
+			},
+		},
+	}
+}
+
+func resourceZabbixAutoregistrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	d.SetId(autoregistrationID)
+	return resourceZabbixAutoregistrationUpdate(ctx, d, meta)
+}
+
+func resourceZabbixAutoregistrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	zabbixVersion := getZabbixServerVersion(meta)
+	if !isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.0.0") {
+		return diag.Errorf("zabbix_autoregistration requires Zabbix 5.0 or higher, server version is %s", zabbixVersion)
+	}
+
+	var autoregistration map[string]interface{}
+	err := api.CallWithErrorParse("autoregistration.get", zabbix.Params{"output": "extend"}, &autoregistration)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	tlsAccept, _ := strconv.Atoi(fmt.Sprint(autoregistration["tls_accept"]))
+	d.Set("tls_accept", tlsAccept)
+	// the PSK identity and the PSK can't be read back, the configured values are kept
+
+	log.Printf("[DEBUG] Autoregistration accepts connections %d\n", tlsAccept)
+	return nil
+}
+
+func resourceZabbixAutoregistrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	zabbixVersion := getZabbixServerVersion(meta)
+	if !isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.0.0") {
+		return diag.Errorf("zabbix_autoregistration requires Zabbix 5.0 or higher, server version is %s", zabbixVersion)
+	}
+
+	autoregistration := autoregistrationObject{
+		TLSAccept: d.Get("tls_accept").(int),
+	}
+	if autoregistration.TLSAccept&tlsAcceptPSK != 0 {
+		if d.Get("tls_psk").(string) == "" {
+			return diag.Errorf("tls_psk_identity and tls_psk are required to accept PSK encrypted connections")
+		}
+		// the PSK is only sent when it changes, e.g. not when enabling unencrypted connections
+		if d.IsNewResource() || d.HasChanges("tls_psk_identity", "tls_psk") {
+			autoregistration.TLSPSKIdentity = d.Get("tls_psk_identity").(string)
+			autoregistration.TLSPSK = d.Get("tls_psk").(string)
+		}
+	}
+
+	_, err := api.CallWithError("autoregistration.update", autoregistration)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return resourceZabbixAutoregistrationRead(ctx, d, meta)
+}
+
+// resourceZabbixAutoregistrationDelete only removes the autoregistration from
+// the state, the Zabbix server keeps its current configuration
+func resourceZabbixAutoregistrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,58 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccZabbixAutoregistration_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfZabbixVersionLower(t, "5.0.0")
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixAutoregistrationConfig(3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_autoregistration.autoregistration", "id", "autoregistration"),
+					resource.TestCheckResourceAttr("zabbix_autoregistration.autoregistration", "tls_accept", "3"),
+					resource.TestCheckResourceAttr("zabbix_autoregistration.autoregistration", "tls_psk_identity", "terraform"),
+				),
+			},
+			{
+				Config: testAccZabbixAutoregistrationConfig(2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_autoregistration.autoregistration", "tls_accept", "2"),
+				),
+			},
+			{
+				ResourceName:            "zabbix_autoregistration.autoregistration",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"tls_psk_identity", "tls_psk"},
+			},
+			{
+				Config: `
+					resource "zabbix_autoregistration" "autoregistration" {}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_autoregistration.autoregistration", "tls_accept", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccZabbixAutoregistrationConfig(tlsAccept int) string {
+	return fmt.Sprintf(`
+		resource "zabbix_autoregistration" "autoregistration" {
+			tls_accept = %d
+			tls_psk_identity = "terraform"
+			tls_psk = "1f87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952"
+		}
+	`, tlsAccept)
+}
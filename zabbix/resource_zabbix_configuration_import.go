@@ -0,0 +1,431 @@
+package zabbix
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigurationImportObjects are the object classes accepted by the rules of configuration.import
+// https://www.zabbix.com/documentation/current/manual/api/reference/configuration/import
+var ConfigurationImportObjects = []string{
+	"applications",
+	"discoveryRules",
+	"graphs",
+	"groups",
+	"host_groups",
+	"hosts",
+	"httptests",
+	"images",
+	"items",
+	"maps",
+	"mediaTypes",
+	"screens",
+	"template_groups",
+	"templateDashboards",
+	"templateLinkage",
+	"templates",
+	"templateScreens",
+	"triggers",
+	"valueMaps",
+}
+
+// configurationDateRegexps match the export date in each format, it changes
+// on every export and is ignored when looking for drift
+var configurationDateRegexps = map[string]*regexp.Regexp{
+	"xml":  regexp.MustCompile(`<date>[^<]*</date>`),
+	"json": regexp.MustCompile(`"date"\s*:\s*"[^"]*",?`),
+	"yaml": regexp.MustCompile(`(?m)^\s*date:.*$`),
+}
+
+// configurationDocument holds the templates and hosts of an exported configuration
+type configurationDocument struct {
+	Templates []struct {
+		Template string `json:"template" xml:"template" yaml:"template"`
+	} `json:"templates" xml:"templates>template" yaml:"templates"`
+	Hosts []struct {
+		Host string `json:"host" xml:"host" yaml:"host"`
+	} `json:"hosts" xml:"hosts>host" yaml:"hosts"`
+}
+
+func resourceZabbixConfigurationImport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixConfigurationImportCreate,
+		ReadContext:   resourceZabbixConfigurationImportRead,
+		UpdateContext: resourceZabbixConfigurationImportUpdate,
+		DeleteContext: resourceZabbixConfigurationImportDelete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffVersions("zabbix_configuration_import", "",
+				versionedAttribute{attribute: "format", minVersion: "5.2.0", value: "yaml"},
+			),
+			resourceZabbixConfigurationImportCustomizeDiff,
+		),
+		Schema: map[string]*schema.Schema{
+			"format": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Format of the configuration: xml, json or yaml (Zabbix 5.2+).",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if _, ok := configurationDateRegexps[v]; !ok {
+						errs = append(errs, fmt.Errorf("%q, must be one of xml, json or yaml, got %s", key, v))
+					}
+					return
+				},
+			},
+			"source": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"source", "source_file"},
+				Description:  "Configuration to import.",
+			},
+			"source_file": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"source", "source_file"},
+				Description:  "Path of the file containing the configuration to import.",
+			},
+			"rule": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Object class the rule applies to, e.g. templates.",
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(string)
+								for _, object := range ConfigurationImportObjects {
+									if v == object {
+										return
+									}
+								}
+								errs = append(errs, fmt.Errorf("%q, must be one of %s, got %s", key, strings.Join(ConfigurationImportObjects, ", "), v))
+								return
+							},
+						},
+						"create_missing": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"update_existing": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"delete_missing": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"source_hash": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 of the imported configuration.",
+			},
+			"exported_hash": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 of the configuration exported right after the import.",
+			},
+			"template_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "IDs of the imported templates.",
+			},
+			"host_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "IDs of the imported hosts.",
+			},
+		},
+	}
+}
+
+func resourceZabbixConfigurationImportCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidate()
+	err := importConfiguration(d, meta.(*providerClient).api)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId(resource.UniqueId())
+	return nil
+}
+
+func resourceZabbixConfigurationImportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	source, err := getConfigurationSource(d)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	format := d.Get("format").(string)
+
+	templateIDs, hostIDs, found, err := getConfigurationObjectIDs(api, format, source)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if !found {
+		log.Printf("[DEBUG] Some objects of configuration import %s don't exist anymore", d.Id())
+		d.Set("source_hash", "")
+		return nil
+	}
+
+	exported, err := exportConfiguration(api, format, templateIDs, hostIDs)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	if hashConfiguration(format, exported) != d.Get("exported_hash").(string) {
+		log.Printf("[DEBUG] Configuration import %s drifted from the imported configuration", d.Id())
+		// an empty source_hash makes the next plan import the configuration again
+		d.Set("source_hash", "")
+	}
+
+	d.Set("template_ids", templateIDs)
+	d.Set("host_ids", hostIDs)
+	return nil
+}
+
+func resourceZabbixConfigurationImportUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidate()
+	return diagFromErr(importConfiguration(d, meta.(*providerClient).api))
+}
+
+// resourceZabbixConfigurationImportDelete only removes the import from the state,
+// the imported objects are kept on the Zabbix server
+func resourceZabbixConfigurationImportDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// resourceZabbixConfigurationImportCustomizeDiff plans a new import when the
+// configuration file changed or when drift was detected by Read
+func resourceZabbixConfigurationImportCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source") || !d.NewValueKnown("source_file") {
+		return d.SetNewComputed("source_hash")
+	}
+
+	var source string
+	if v, ok := d.GetOk("source_file"); ok {
+		content, err := os.ReadFile(v.(string))
+		if err != nil {
+			return err
+		}
+		source = string(content)
+	} else {
+		source = d.Get("source").(string)
+	}
+
+	if hash := hashString(source); hash != d.Get("source_hash").(string) {
+		return d.SetNew("source_hash", hash)
+	}
+	return nil
+}
+
+// importConfiguration imports the configured source and records the state
+// of the imported objects to detect drift
+func importConfiguration(d *schema.ResourceData, api *zabbix.API) error {
+	source, err := getConfigurationSource(d)
+	if err != nil {
+		return err
+	}
+	format := d.Get("format").(string)
+
+	rules := map[string]interface{}{}
+	for _, r := range d.Get("rule").(*schema.Set).List() {
+		rule := r.(map[string]interface{})
+		// only the enabled options are sent, not every object class accepts all of them
+		options := map[string]bool{}
+		if rule["create_missing"].(bool) {
+			options["createMissing"] = true
+		}
+		if rule["update_existing"].(bool) {
+			options["updateExisting"] = true
+		}
+		if rule["delete_missing"].(bool) {
+			options["deleteMissing"] = true
+		}
+		rules[rule["object"].(string)] = options
+	}
+
+	_, err = api.CallWithError("configuration.import", zabbix.Params{
+		"format": format,
+		"source": source,
+		"rules":  rules,
+	})
+	if err != nil {
+		return err
+	}
+
+	templateIDs, hostIDs, found, err := getConfigurationObjectIDs(api, format, source)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("Some templates or hosts of the configuration were not imported, check the import rules")
+	}
+
+	exported, err := exportConfiguration(api, format, templateIDs, hostIDs)
+	if err != nil {
+		return err
+	}
+
+	d.Set("source_hash", hashString(source))
+	d.Set("exported_hash", hashConfiguration(format, exported))
+	d.Set("template_ids", templateIDs)
+	d.Set("host_ids", hostIDs)
+	return nil
+}
+
+func getConfigurationSource(d *schema.ResourceData) (string, error) {
+	if v, ok := d.GetOk("source_file"); ok {
+		content, err := os.ReadFile(v.(string))
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	}
+	return d.Get("source").(string), nil
+}
+
+// getConfigurationObjectIDs returns the IDs of the templates and hosts defined
+// in source, found is false when some of them don't exist on the server
+func getConfigurationObjectIDs(api *zabbix.API, format, source string) (templateIDs, hostIDs []string, found bool, err error) {
+	templateNames, hostNames, err := parseConfigurationObjects(format, source)
+	if err != nil {
+		return
+	}
+
+	if len(templateNames) > 0 {
+		var templates []templateRef
+		err = api.CallWithErrorParse("template.get", zabbix.Params{
+			"output": []string{"templateid", "host"},
+			"filter": map[string]interface{}{"host": templateNames},
+		}, &templates)
+		if err != nil {
+			return
+		}
+		for _, t := range templates {
+			templateIDs = append(templateIDs, t.TemplateID)
+		}
+	}
+
+	if len(hostNames) > 0 {
+		var hosts []hostData
+		err = api.CallWithErrorParse("host.get", zabbix.Params{
+			"output": []string{"hostid", "host"},
+			"filter": map[string]interface{}{"host": hostNames},
+		}, &hosts)
+		if err != nil {
+			return
+		}
+		for _, h := range hosts {
+			hostIDs = append(hostIDs, h.HostID)
+		}
+	}
+
+	found = len(templateIDs) == len(templateNames) && len(hostIDs) == len(hostNames)
+	return
+}
+
+// parseConfigurationObjects returns the technical names of the templates and
+// hosts defined in an exported configuration
+func parseConfigurationObjects(format, source string) (templateNames, hostNames []string, err error) {
+	var document configurationDocument
+
+	switch format {
+	case "xml":
+		var export struct {
+			XMLName xml.Name `xml:"zabbix_export"`
+			configurationDocument
+		}
+		err = xml.Unmarshal([]byte(source), &export)
+		document = export.configurationDocument
+	case "json":
+		var export struct {
+			ZabbixExport configurationDocument `json:"zabbix_export"`
+		}
+		err = json.Unmarshal([]byte(source), &export)
+		document = export.ZabbixExport
+	case "yaml":
+		var export struct {
+			ZabbixExport configurationDocument `yaml:"zabbix_export"`
+		}
+		err = yaml.Unmarshal([]byte(source), &export)
+		document = export.ZabbixExport
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to parse the %s configuration: %s", format, err)
+	}
+
+	for _, t := range document.Templates {
+		templateNames = append(templateNames, t.Template)
+	}
+	for _, h := range document.Hosts {
+		hostNames = append(hostNames, h.Host)
+	}
+	return
+}
+
+// exportConfiguration returns the configuration of the given templates and hosts
+func exportConfiguration(api *zabbix.API, format string, templateIDs, hostIDs []string) (string, error) {
+	options := map[string]interface{}{}
+	if len(templateIDs) > 0 {
+		options["templates"] = templateIDs
+	}
+	if len(hostIDs) > 0 {
+		options["hosts"] = hostIDs
+	}
+
+	response, err := api.CallWithError("configuration.export", zabbix.Params{
+		"format":  format,
+		"options": options,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	exported, ok := response.Result.(string)
+	if !ok {
+		return "", fmt.Errorf("Unexpected configuration.export result %v", response.Result)
+	}
+	return exported, nil
+}
+
+// hashConfiguration returns the SHA-256 of an exported configuration, ignoring the export date
+func hashConfiguration(format, configuration string) string {
+	if re, ok := configurationDateRegexps[format]; ok {
+		configuration = re.ReplaceAllString(configuration, "")
+	}
+	return hashString(strings.TrimSpace(configuration))
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,148 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccZabbixConfigurationImport_Basic(t *testing.T) {
+	strID := acctest.RandString(5)
+	templateName := fmt.Sprintf("import_template_%s", strID)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixConfigurationImportConfig(strID, templateName, "first description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_configuration_import.import_test", "template_ids.#", "1"),
+					resource.TestCheckResourceAttrSet("zabbix_configuration_import.import_test", "source_hash"),
+					resource.TestCheckResourceAttrSet("zabbix_configuration_import.import_test", "exported_hash"),
+					resource.TestCheckResourceAttr("data.zabbix_template.imported", "description", "first description"),
+				),
+			},
+			{
+				Config: testAccZabbixConfigurationImportConfig(strID, templateName, "second description"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zabbix_template.imported", "description", "second description"),
+					resource.TestCheckResourceAttrPair("data.zabbix_template.imported", "id", "zabbix_configuration_import.import_test", "template_ids.0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccZabbixConfigurationImportConfig(strID, templateName, description string) string {
+	return fmt.Sprintf(`
+		data "zabbix_server" "test" {
+			compare_version = "6.2.0"
+		}
+
+		resource "zabbix_configuration_import" "import_test" {
+			format = "xml"
+			source = <<-EOT
+				<?xml version="1.0" encoding="UTF-8"?>
+				<zabbix_export>
+					<version>4.0</version>
+					<groups>
+						<group>
+							<name>import group %s</name>
+						</group>
+					</groups>
+					<templates>
+						<template>
+							<template>%s</template>
+							<name>%s</name>
+							<description>%s</description>
+							<groups>
+								<group>
+									<name>import group %s</name>
+								</group>
+							</groups>
+						</template>
+					</templates>
+				</zabbix_export>
+			EOT
+
+			rule {
+				object = data.zabbix_server.test.server_version_ge ? "template_groups" : "groups"
+				create_missing = true
+			}
+
+			rule {
+				object = "templates"
+				create_missing = true
+				update_existing = true
+			}
+		}
+
+		data "zabbix_template" "imported" {
+			host = "%s"
+			depends_on = [zabbix_configuration_import.import_test]
+		}
+	`, strID, templateName, templateName, description, strID, templateName)
+}
+
+func TestParseConfigurationObjectsYAML(t *testing.T) {
+	source := `zabbix_export:
+    version: '5.4'
+    templates:
+        -   uuid: 7df96b18c230490a9a0a9e2307226338
+            template: "Template App"
+            name: 'Template App'
+        -
+            template: 123
+    hosts:
+      - host: web-01
+        name: web-01
+`
+	templateNames, hostNames, err := parseConfigurationObjects("yaml", source)
+	if err != nil {
+		t.Fatalf("expected the configuration to be parsed, got %s", err)
+	}
+	if !reflect.DeepEqual(templateNames, []string{"Template App", "123"}) {
+		t.Fatalf("expected the template names, got %v", templateNames)
+	}
+	if !reflect.DeepEqual(hostNames, []string{"web-01"}) {
+		t.Fatalf("expected the host names, got %v", hostNames)
+	}
+
+	if _, _, err := parseConfigurationObjects("yaml", "zabbix_export:\n  templates: [\n"); err == nil {
+		t.Fatal("expected an invalid configuration to fail")
+	}
+}
+
+func TestZabbixConfigurationImportCustomizeDiffFormat(t *testing.T) {
+	config := func(format string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"format": format,
+			"source": "",
+			"rule": []interface{}{
+				map[string]interface{}{"object": "templates", "create_missing": true},
+			},
+		})
+	}
+	meta := func(version string) *providerClient {
+		return &providerClient{api: zabbix.NewAPI("http://localhost/api_jsonrpc.php"), cache: newLookupCache(), version: &serverVersion{version: version}}
+	}
+
+	_, err := resourceZabbixConfigurationImport().Diff(context.Background(), nil, config("yaml"), meta("5.0.0"))
+	if err == nil || !regexp.MustCompile("format yaml requires Zabbix 5.2 or higher").MatchString(err.Error()) {
+		t.Fatalf("expected the yaml format to fail the plan before Zabbix 5.2, got %v", err)
+	}
+
+	for _, c := range []struct{ format, version string }{{"json", "5.0.0"}, {"yaml", "5.2.0"}} {
+		if _, err := resourceZabbixConfigurationImport().Diff(context.Background(), nil, config(c.format), meta(c.version)); err != nil {
+			t.Fatalf("expected the %s format to be planned on Zabbix %s, got %s", c.format, c.version, err)
+		}
+	}
+}
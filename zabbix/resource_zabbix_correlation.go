@@ -0,0 +1,440 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// correlationObject represent Zabbix correlation object
+// https://www.zabbix.com/documentation/current/manual/api/reference/correlation/object
+type correlationObject struct {
+	CorrelationID string                 `json:"correlationid,omitempty"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description"`
+	Status        int                    `json:"status,string"`
+	Filter        correlationFilter      `json:"filter"`
+	Operations    []correlationOperation `json:"operations"`
+}
+
+type correlationFilter struct {
+	EvalType   int                    `json:"evaltype,string"`
+	Formula    string                 `json:"formula,omitempty"`
+	Conditions []correlationCondition `json:"conditions"`
+}
+
+type correlationCondition struct {
+	Type      int    `json:"type,string"`
+	Tag       string `json:"tag,omitempty"`
+	GroupID   string `json:"groupid,omitempty"`
+	OldTag    string `json:"oldtag,omitempty"`
+	NewTag    string `json:"newtag,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Operator  int    `json:"operator,string,omitempty"`
+	FormulaID string `json:"formulaid,omitempty"`
+}
+
+type correlationOperation struct {
+	Type int `json:"type,string"`
+}
+
+// CorrelationConditionTypes zabbix different correlation condition type
+var CorrelationConditionTypes = map[string]int{
+	"old_event_tag":        0,
+	"new_event_tag":        1,
+	"new_event_host_group": 2,
+	"event_tag_pair":       3,
+	"old_event_tag_value":  4,
+	"new_event_tag_value":  5,
+}
+
+// CorrelationOperationTypes zabbix different correlation operation type
+var CorrelationOperationTypes = map[string]int{
+	"close_old_events": 0,
+	"close_new_event":  1,
+}
+
+func resourceZabbixCorrelation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixCorrelationCreate,
+		ReadContext:   resourceZabbixCorrelationRead,
+		UpdateContext: resourceZabbixCorrelationUpdate,
+		DeleteContext: resourceZabbixCorrelationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceZabbixCorrelationCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the correlation.",
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"eval_type": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Condition evaluation method, 0 and/or, 1 and, 2 or, 3 custom expression.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 3 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 3 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"formula": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Custom expression of the conditions, required by eval_type 3.",
+			},
+			"condition": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     schemaCorrelationCondition(),
+			},
+			"operations": &schema.Schema{
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+						v := val.(string)
+						if _, ok := CorrelationOperationTypes[v]; !ok {
+							errs = append(errs, fmt.Errorf("%q, must be close_old_events or close_new_event, got %s", key, v))
+						}
+						return
+					},
+				},
+				Required:    true,
+				MinItems:    1,
+				Description: "Operations applied when the conditions match, close_old_events and/or close_new_event.",
+			},
+		},
+	}
+}
+
+func schemaCorrelationCondition() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if _, ok := CorrelationConditionTypes[v]; !ok {
+						errs = append(errs, fmt.Errorf("%q, must be a valid correlation condition type, got %s", key, v))
+					}
+					return
+				},
+			},
+			"tag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"old_tag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"new_tag": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"value": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"operator": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Condition operator, 0 equals, 1 does not equal, 2 contains, 3 does not contain.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 3 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 3 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"formula_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "ID used to reference the condition from a custom expression.",
+			},
+		},
+	}
+}
+
+func resourceZabbixCorrelationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	correlation, err := createCorrelationObject(d)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return createRetry(ctx, d, meta, createCorrelation, *correlation, resourceZabbixCorrelationRead)
+}
+
+func resourceZabbixCorrelationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	correlation, err := getCorrelationByID(d.Id(), api)
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "Correlation", err))
+	}
+
+	d.Set("name", correlation.Name)
+	d.Set("description", correlation.Description)
+	d.Set("enabled", correlation.Status == 0)
+	d.Set("eval_type", correlation.Filter.EvalType)
+	formula := ""
+	if correlation.Filter.EvalType == 3 {
+		formula = correlation.Filter.Formula
+	}
+	d.Set("formula", formula)
+
+	conditionTypes := make(map[int]string, len(CorrelationConditionTypes))
+	for name, id := range CorrelationConditionTypes {
+		conditionTypes[id] = name
+	}
+	terraformConditions := make([]interface{}, len(correlation.Filter.Conditions))
+	for i, condition := range correlation.Filter.Conditions {
+		terraformCondition := map[string]interface{}{
+			"type":       conditionTypes[condition.Type],
+			"tag":        condition.Tag,
+			"group_id":   condition.GroupID,
+			"old_tag":    condition.OldTag,
+			"new_tag":    condition.NewTag,
+			"value":      condition.Value,
+			"operator":   condition.Operator,
+			"formula_id": "",
+		}
+		// formula IDs are generated by the server unless a custom expression is used
+		if correlation.Filter.EvalType == 3 {
+			terraformCondition["formula_id"] = condition.FormulaID
+		}
+		terraformConditions[i] = terraformCondition
+	}
+	d.Set("condition", terraformConditions)
+
+	operationTypes := make(map[int]string, len(CorrelationOperationTypes))
+	for name, id := range CorrelationOperationTypes {
+		operationTypes[id] = name
+	}
+	operations := make([]string, len(correlation.Operations))
+	for i, operation := range correlation.Operations {
+		operations[i] = operationTypes[operation.Type]
+	}
+	d.Set("operations", operations)
+
+	log.Printf("[DEBUG] Correlation name is %s\n", correlation.Name)
+	return nil
+}
+
+func resourceZabbixCorrelationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	correlation, err := createCorrelationObject(d)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	correlation.CorrelationID = d.Id()
+	return createRetry(ctx, d, meta, updateCorrelation, *correlation, resourceZabbixCorrelationRead)
+}
+
+func resourceZabbixCorrelationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	_, err := api.CallWithError("correlation.delete", []string{d.Id()})
+	return diagFromErr(err)
+}
+
+// resourceZabbixCorrelationCustomizeDiff fails the plan when the conditions are
+// invalid, createCorrelationObject checks them again before the requests
+func resourceZabbixCorrelationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"eval_type", "formula", "condition"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+	_, err := createCorrelationObject(d)
+	return err
+}
+
+func createCorrelationObject(d resourceGetter) (*correlationObject, error) {
+	correlation := correlationObject{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Status:      1,
+		Filter: correlationFilter{
+			EvalType: d.Get("eval_type").(int),
+			Formula:  d.Get("formula").(string),
+		},
+	}
+	if d.Get("enabled").(bool) {
+		correlation.Status = 0
+	}
+
+	customExpression := correlation.Filter.EvalType == 3
+	if customExpression && correlation.Filter.Formula == "" {
+		return nil, attributeErrorf("formula", "Correlation formula is required by the custom expression eval_type 3")
+	}
+	if !customExpression && correlation.Filter.Formula != "" {
+		return nil, attributeErrorf("formula", "Correlation formula is only used by the custom expression eval_type 3, got %d", correlation.Filter.EvalType)
+	}
+
+	for _, c := range d.Get("condition").(*schema.Set).List() {
+		value := c.(map[string]interface{})
+		condition := correlationCondition{
+			Type:      CorrelationConditionTypes[value["type"].(string)],
+			Tag:       value["tag"].(string),
+			GroupID:   value["group_id"].(string),
+			OldTag:    value["old_tag"].(string),
+			NewTag:    value["new_tag"].(string),
+			Value:     value["value"].(string),
+			Operator:  value["operator"].(int),
+			FormulaID: value["formula_id"].(string),
+		}
+		if err := validateCorrelationCondition(value["type"].(string), condition); err != nil {
+			return nil, err
+		}
+		if customExpression && condition.FormulaID == "" {
+			return nil, attributeErrorf("condition", "Correlation condition %s requires a formula_id with the custom expression eval_type 3", value["type"].(string))
+		}
+		if !customExpression {
+			condition.FormulaID = ""
+		}
+		correlation.Filter.Conditions = append(correlation.Filter.Conditions, condition)
+	}
+
+	for _, operation := range d.Get("operations").(*schema.Set).List() {
+		correlation.Operations = append(correlation.Operations, correlationOperation{
+			Type: CorrelationOperationTypes[operation.(string)],
+		})
+	}
+	return &correlation, nil
+}
+
+// validateCorrelationCondition checks that only the properties used by the
+// condition type are set
+func validateCorrelationCondition(conditionType string, condition correlationCondition) error {
+	var required []string
+	var unused string
+	maxOperator := 0
+
+	switch conditionType {
+	case "old_event_tag", "new_event_tag":
+		if condition.Tag == "" {
+			required = append(required, "tag")
+		}
+		if condition.GroupID != "" || condition.OldTag != "" || condition.NewTag != "" || condition.Value != "" {
+			unused = "group_id, old_tag, new_tag, value"
+		}
+	case "new_event_host_group":
+		maxOperator = 1
+		if condition.GroupID == "" {
+			required = append(required, "group_id")
+		}
+		if condition.Tag != "" || condition.OldTag != "" || condition.NewTag != "" || condition.Value != "" {
+			unused = "tag, old_tag, new_tag, value"
+		}
+	case "event_tag_pair":
+		if condition.OldTag == "" {
+			required = append(required, "old_tag")
+		}
+		if condition.NewTag == "" {
+			required = append(required, "new_tag")
+		}
+		if condition.Tag != "" || condition.GroupID != "" || condition.Value != "" {
+			unused = "tag, group_id, value"
+		}
+	case "old_event_tag_value", "new_event_tag_value":
+		maxOperator = 3
+		if condition.Tag == "" {
+			required = append(required, "tag")
+		}
+		if condition.GroupID != "" || condition.OldTag != "" || condition.NewTag != "" {
+			unused = "group_id, old_tag, new_tag"
+		}
+	}
+
+	if len(required) > 0 {
+		return fmt.Errorf("Correlation condition %s requires %s", conditionType, strings.Join(required, " and "))
+	}
+	if unused != "" {
+		return fmt.Errorf("Correlation condition %s doesn't use %s", conditionType, unused)
+	}
+	if condition.Operator > maxOperator {
+		return fmt.Errorf("Correlation condition %s operator must be between 0 and %d inclusive, got %d", conditionType, maxOperator, condition.Operator)
+	}
+	return nil
+}
+
+func getCorrelationByID(id string, api *zabbix.API) (*correlationObject, error) {
+	var correlations []correlationObject
+
+	err := api.CallWithErrorParse("correlation.get", zabbix.Params{
+		"correlationids":   id,
+		"output":           "extend",
+		"selectFilter":     "extend",
+		"selectOperations": "extend",
+	}, &correlations)
+	if err != nil {
+		return nil, err
+	}
+	err = expectOneResult("Correlation", id, len(correlations))
+	if err != nil {
+		return nil, err
+	}
+	return &correlations[0], nil
+}
+
+func createCorrelation(correlation interface{}, api *zabbix.API) (id string, err error) {
+	response, err := api.CallWithError("correlation.create", []correlationObject{correlation.(correlationObject)})
+	if err != nil {
+		return
+	}
+
+	result := response.Result.(map[string]interface{})
+	id = result["correlationids"].([]interface{})[0].(string)
+	return
+}
+
+func updateCorrelation(correlation interface{}, api *zabbix.API) (id string, err error) {
+	zabbixCorrelation := correlation.(correlationObject)
+
+	_, err = api.CallWithError("correlation.update", []correlationObject{zabbixCorrelation})
+	if err != nil {
+		return
+	}
+	id = zabbixCorrelation.CorrelationID
+	return
+}
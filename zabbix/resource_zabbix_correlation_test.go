@@ -0,0 +1,181 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccZabbixCorrelation_Basic(t *testing.T) {
+	resourceName := "zabbix_correlation.correlation_test"
+	strID := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixCorrelationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixCorrelationConfig(strID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("correlation_%s", strID)),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "eval_type", "0"),
+					resource.TestCheckResourceAttr(resourceName, "condition.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "condition.*", map[string]string{"type": "event_tag_pair", "old_tag": "cluster", "new_tag": "cluster"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "condition.*", map[string]string{"type": "new_event_tag_value", "tag": "service", "value": "db", "operator": "2"}),
+					resource.TestCheckResourceAttr(resourceName, "operations.#", "1"),
+				),
+			},
+			{
+				Config: testAccZabbixCorrelationCustomExpression(strID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "eval_type", "3"),
+					resource.TestCheckResourceAttr(resourceName, "formula", "A or B"),
+					resource.TestCheckResourceAttr(resourceName, "operations.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccZabbixCorrelation_InvalidCondition(t *testing.T) {
+	strID := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixCorrelationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccZabbixCorrelationInvalidCondition(strID),
+				ExpectError: regexp.MustCompile("Correlation condition event_tag_pair requires new_tag"),
+			},
+		},
+	})
+}
+
+func testAccCheckZabbixCorrelationDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(*providerClient).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "zabbix_correlation" {
+			continue
+		}
+
+		_, err := getCorrelationByID(rs.Primary.ID, api)
+		if err == nil {
+			return fmt.Errorf("Correlation still exists %s", rs.Primary.ID)
+		}
+		if !isNotFoundError(err) {
+			return fmt.Errorf("expected a not found error, got : %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func testAccZabbixCorrelationConfig(strID string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_correlation" "correlation_test" {
+			name = "correlation_%s"
+			description = "close duplicate cluster problems"
+
+			condition {
+				type = "event_tag_pair"
+				old_tag = "cluster"
+				new_tag = "cluster"
+			}
+
+			condition {
+				type = "new_event_tag_value"
+				tag = "service"
+				value = "db"
+				operator = 2
+			}
+
+			operations = ["close_old_events"]
+		}
+	`, strID)
+}
+
+func testAccZabbixCorrelationCustomExpression(strID string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_host_group" "group_test" {
+			name = "correlation_group_%s"
+		}
+
+		resource "zabbix_correlation" "correlation_test" {
+			name = "correlation_%s"
+			enabled = false
+			eval_type = 3
+			formula = "A or B"
+
+			condition {
+				type = "new_event_host_group"
+				group_id = zabbix_host_group.group_test.id
+				formula_id = "A"
+			}
+
+			condition {
+				type = "old_event_tag"
+				tag = "cluster"
+				formula_id = "B"
+			}
+
+			operations = ["close_old_events", "close_new_event"]
+		}
+	`, strID, strID)
+}
+
+func testAccZabbixCorrelationInvalidCondition(strID string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_correlation" "correlation_test" {
+			name = "correlation_%s"
+
+			condition {
+				type = "event_tag_pair"
+				old_tag = "cluster"
+			}
+
+			operations = ["close_new_event"]
+		}
+	`, strID)
+}
+
+func TestZabbixCorrelationCustomizeDiff(t *testing.T) {
+	config := func(condition map[string]interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":       "correlation",
+			"condition":  []interface{}{condition},
+			"operations": []interface{}{"close_old_events"},
+		})
+	}
+
+	_, err := resourceZabbixCorrelation().Diff(context.Background(), nil, config(map[string]interface{}{
+		"type":    "event_tag_pair",
+		"old_tag": "service",
+	}), nil)
+	if err == nil || !regexp.MustCompile("Correlation condition event_tag_pair requires new_tag").MatchString(err.Error()) {
+		t.Fatalf("expected the invalid condition to fail the plan, got %v", err)
+	}
+
+	_, err = resourceZabbixCorrelation().Diff(context.Background(), nil, config(map[string]interface{}{
+		"type":    "event_tag_pair",
+		"old_tag": "service",
+		"new_tag": "service",
+	}), nil)
+	if err != nil {
+		t.Fatalf("expected the valid condition to be planned, got %s", err)
+	}
+}
@@ -0,0 +1,199 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// macroTypeSecret is the type of secret text macros, their value is never returned by the API
+const macroTypeSecret = 1
+
+// globalMacro represent Zabbix global macro object
+// https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/object#global_macro
+type globalMacro struct {
+	GlobalMacroID string `json:"globalmacroid,omitempty"`
+	Macro         string `json:"macro"`
+	Value         string `json:"value,omitempty"`
+	Type          string `json:"type,omitempty"`
+	Description   string `json:"description,omitempty"`
+}
+
+func resourceZabbixGlobalMacro() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixGlobalMacroCreate,
+		ReadContext:   resourceZabbixGlobalMacroRead,
+		UpdateContext: resourceZabbixGlobalMacroUpdate,
+		DeleteContext: resourceZabbixGlobalMacroDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeDiffVersions("zabbix_global_macro", "",
+			versionedAttribute{attribute: "type", minVersion: "5.0.0"},
+			versionedAttribute{attribute: "description", minVersion: "4.4.0"},
+		),
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the macro, without the {$ and } delimiters.",
+				ValidateFunc: validateMacroName,
+			},
+			"value": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Value of the macro.",
+			},
+			"type": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Type of the macro (Zabbix 5.0+).",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 2 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 2 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Description of the macro (Zabbix 4.4+).",
+			},
+		},
+	}
+}
+
+func validateMacroName(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if strings.HasPrefix(v, "{$") || strings.HasSuffix(v, "}") {
+		errs = append(errs, fmt.Errorf("%q, must not contain the {$ and } delimiters, got %s", key, v))
+	}
+	return
+}
+
+func resourceZabbixGlobalMacroCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	macro, err := createGlobalMacroObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return createRetry(ctx, d, meta, createGlobalMacro, *macro, resourceZabbixGlobalMacroRead)
+}
+
+func resourceZabbixGlobalMacroRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	macro, err := getGlobalMacroByID(d.Id(), api)
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "Global macro", err))
+	}
+
+	name, err := getTerraformMacroName(macro.Macro)
+	if err != nil {
+		return diagFromErr(err)
+	}
+	macroType, _ := strconv.Atoi(macro.Type)
+
+	d.Set("name", name)
+	d.Set("type", macroType)
+	d.Set("description", macro.Description)
+	// the value of secret macros can't be read back
+	if macroType != macroTypeSecret {
+		d.Set("value", macro.Value)
+	}
+
+	log.Printf("[DEBUG] Global macro name is %s\n", macro.Macro)
+	return nil
+}
+
+func resourceZabbixGlobalMacroUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	macro, err := createGlobalMacroObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	macro.GlobalMacroID = d.Id()
+	return createRetry(ctx, d, meta, updateGlobalMacro, *macro, resourceZabbixGlobalMacroRead)
+}
+
+func resourceZabbixGlobalMacroDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	_, err := api.CallWithError("usermacro.deleteglobal", []string{d.Id()})
+	return diagFromErr(err)
+}
+
+func createGlobalMacroObject(d *schema.ResourceData, zabbixVersion string) (*globalMacro, error) {
+	macro := globalMacro{
+		Macro: fmt.Sprintf("{$%s}", d.Get("name").(string)),
+		Value: d.Get("value").(string),
+	}
+
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.0.0") {
+		macro.Type = strconv.Itoa(d.Get("type").(int))
+	} else if d.Get("type").(int) != 0 {
+		return nil, attributeErrorf("type", "Macro type requires Zabbix 5.0 or higher, server version is %s", zabbixVersion)
+	}
+
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "4.4.0") {
+		macro.Description = d.Get("description").(string)
+	} else if d.Get("description").(string) != "" {
+		return nil, attributeErrorf("description", "Macro description requires Zabbix 4.4 or higher, server version is %s", zabbixVersion)
+	}
+	return &macro, nil
+}
+
+func getGlobalMacroByID(id string, api *zabbix.API) (*globalMacro, error) {
+	var macros []globalMacro
+
+	err := api.CallWithErrorParse("usermacro.get", zabbix.Params{
+		"globalmacroids": id,
+		"globalmacro":    true,
+		"output":         "extend",
+	}, &macros)
+	if err != nil {
+		return nil, err
+	}
+	err = expectOneResult("Global macro", id, len(macros))
+	if err != nil {
+		return nil, err
+	}
+	return &macros[0], nil
+}
+
+func createGlobalMacro(macro interface{}, api *zabbix.API) (id string, err error) {
+	response, err := api.CallWithError("usermacro.createglobal", []globalMacro{macro.(globalMacro)})
+	if err != nil {
+		return
+	}
+
+	result := response.Result.(map[string]interface{})
+	id = result["globalmacroids"].([]interface{})[0].(string)
+	return
+}
+
+func updateGlobalMacro(macro interface{}, api *zabbix.API) (id string, err error) {
+	zabbixMacro := macro.(globalMacro)
+
+	_, err = api.CallWithError("usermacro.updateglobal", []globalMacro{zabbixMacro})
+	if err != nil {
+		return
+	}
+	id = zabbixMacro.GlobalMacroID
+	return
+}
@@ -0,0 +1,70 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccZabbixGlobalMacro_Basic(t *testing.T) {
+	strID := acctest.RandString(5)
+	macroName := fmt.Sprintf("TF_MACRO_%s", strID)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixGlobalMacroDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixGlobalMacroConfig(macroName, "first value"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_global_macro.macro_test", "name", macroName),
+					resource.TestCheckResourceAttr("zabbix_global_macro.macro_test", "value", "first value"),
+					resource.TestCheckResourceAttr("zabbix_global_macro.macro_test", "type", "0"),
+				),
+			},
+			{
+				Config: testAccZabbixGlobalMacroConfig(macroName, "second value"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_global_macro.macro_test", "value", "second value"),
+				),
+			},
+			{
+				ResourceName:      "zabbix_global_macro.macro_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckZabbixGlobalMacroDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(*providerClient).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "zabbix_global_macro" {
+			continue
+		}
+
+		_, err := getGlobalMacroByID(rs.Primary.ID, api)
+		if err == nil {
+			return fmt.Errorf("Global macro still exists %s", rs.Primary.ID)
+		}
+		if !isNotFoundError(err) {
+			return fmt.Errorf("expected a not found error, got : %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func testAccZabbixGlobalMacroConfig(macroName, value string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_global_macro" "macro_test" {
+			name = "%s"
+			value = "%s"
+		}
+	`, macroName, value)
+}
@@ -1,11 +1,12 @@
 package zabbix
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"log"
 
 	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -56,10 +57,10 @@ var interfaceSchema *schema.Resource = &schema.Resource{
 
 func resourceZabbixHost() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceZabbixHostCreate,
-		Read:   resourceZabbixHostRead,
-		Update: resourceZabbixHostUpdate,
-		Delete: resourceZabbixHostDelete,
+		CreateContext: resourceZabbixHostCreate,
+		ReadContext:   resourceZabbixHostRead,
+		UpdateContext: resourceZabbixHostUpdate,
+		DeleteContext: resourceZabbixHostDelete,
 		Schema: map[string]*schema.Schema{
 			"host": &schema.Schema{
 				Type:        schema.TypeString,
@@ -120,14 +121,14 @@ func getInterfaces(d *schema.ResourceData) (zabbix.HostInterfaces, error) {
 		typeID, ok := HostInterfaceTypes[interfaceType]
 
 		if !ok {
-			return nil, fmt.Errorf("%s isnt valid interface type", interfaceType)
+			return nil, attributeErrorf("interfaces", "%s isnt valid interface type", interfaceType)
 		}
 
 		ip := d.Get(prefix + "ip").(string)
 		dns := d.Get(prefix + "dns").(string)
 
 		if ip == "" && dns == "" {
-			return nil, errors.New("Atleast one of two dns or ip must be set")
+			return nil, attributeErrorf("interfaces", "Atleast one of two dns or ip must be set")
 		}
 
 		useip := 1
@@ -155,7 +156,7 @@ func getInterfaces(d *schema.ResourceData) (zabbix.HostInterfaces, error) {
 	return interfaces, nil
 }
 
-func getHostGroups(d *schema.ResourceData, api *zabbix.API) (zabbix.HostGroupIDs, error) {
+func getHostGroups(d *schema.ResourceData, api *zabbix.API, cache *lookupCache) (zabbix.HostGroupIDs, error) {
 	configGroups := d.Get("groups").(*schema.Set)
 	setHostGroups := make([]string, configGroups.Len())
 
@@ -165,51 +166,28 @@ func getHostGroups(d *schema.ResourceData, api *zabbix.API) (zabbix.HostGroupIDs
 
 	log.Printf("[DEBUG] Groups %v\n", setHostGroups)
 
-	groupParams := zabbix.Params{
-		"output": "extend",
-		"filter": map[string]interface{}{
-			"name": setHostGroups,
-		},
-	}
-
-	groups, err := api.HostGroupsGet(groupParams)
+	groupIDs, err := cache.getHostGroupIDs(api, setHostGroups)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if len(groups) < configGroups.Len() {
-		log.Printf("[DEBUG] Not all of the specified groups were found on zabbix server")
-
-		for _, n := range configGroups.List() {
-			found := false
+	hostGroups := make(zabbix.HostGroupIDs, len(setHostGroups))
 
-			for _, g := range groups {
-				if n == g.Name {
-					found = true
-					break
-				}
-			}
-
-			if !found {
-				return nil, fmt.Errorf("Host group %s doesnt exist in zabbix server", n)
-			}
-			log.Printf("[DEBUG] %s exists on zabbix server", n)
+	for i, n := range setHostGroups {
+		groupID, ok := groupIDs[n]
+		if !ok {
+			return nil, attributeErrorf("groups", "Host group %s doesnt exist in zabbix server", n)
 		}
-	}
-
-	hostGroups := make(zabbix.HostGroupIDs, len(groups))
-
-	for i, g := range groups {
 		hostGroups[i] = zabbix.HostGroupID{
-			GroupID: g.GroupID,
+			GroupID: groupID,
 		}
 	}
 
 	return hostGroups, nil
 }
 
-func getTemplates(d *schema.ResourceData, api *zabbix.API) (zabbix.TemplateIDs, error) {
+func getTemplates(d *schema.ResourceData, api *zabbix.API, cache *lookupCache) (zabbix.TemplateIDs, error) {
 	configTemplates := d.Get("templates").(*schema.Set)
 	templateNames := make([]string, configTemplates.Len())
 
@@ -223,51 +201,28 @@ func getTemplates(d *schema.ResourceData, api *zabbix.API) (zabbix.TemplateIDs,
 
 	log.Printf("[DEBUG] Templates %v\n", templateNames)
 
-	groupParams := zabbix.Params{
-		"output": "extend",
-		"filter": map[string]interface{}{
-			"host": templateNames,
-		},
-	}
-
-	templates, err := api.TemplatesGet(groupParams)
+	templateIDs, err := cache.getTemplateIDs(api, templateNames)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if len(templates) < configTemplates.Len() {
-		log.Printf("[DEBUG] Not all of the specified templates were found on zabbix server")
-
-		for _, n := range configTemplates.List() {
-			found := false
+	hostTemplates := make(zabbix.TemplateIDs, len(templateNames))
 
-			for _, g := range templates {
-				if n == g.Name {
-					found = true
-					break
-				}
-			}
-
-			if !found {
-				return nil, fmt.Errorf("Template %s doesnt exist in zabbix server", n)
-			}
-			log.Printf("[DEBUG] Template %s exists on zabbix server", n)
+	for i, n := range templateNames {
+		templateID, ok := templateIDs[n]
+		if !ok {
+			return nil, attributeErrorf("templates", "Template %s doesnt exist in zabbix server", n)
 		}
-	}
-
-	hostTemplates := make(zabbix.TemplateIDs, len(templates))
-
-	for i, t := range templates {
 		hostTemplates[i] = zabbix.TemplateID{
-			TemplateID: t.TemplateID,
+			TemplateID: templateID,
 		}
 	}
 
 	return hostTemplates, nil
 }
 
-func createHostObj(d *schema.ResourceData, api *zabbix.API) (*zabbix.Host, error) {
+func createHostObj(d *schema.ResourceData, api *zabbix.API, cache *lookupCache) (*zabbix.Host, error) {
 	host := zabbix.Host{
 		Host:   d.Get("host").(string),
 		Name:   d.Get("name").(string),
@@ -279,7 +234,7 @@ func createHostObj(d *schema.ResourceData, api *zabbix.API) (*zabbix.Host, error
 		host.Status = 1
 	}
 
-	hostGroups, err := getHostGroups(d, api)
+	hostGroups, err := getHostGroups(d, api, cache)
 
 	if err != nil {
 		return nil, err
@@ -295,7 +250,7 @@ func createHostObj(d *schema.ResourceData, api *zabbix.API) (*zabbix.Host, error
 
 	host.Interfaces = interfaces
 
-	templates, err := getTemplates(d, api)
+	templates, err := getTemplates(d, api, cache)
 
 	if err != nil {
 		return nil, err
@@ -305,13 +260,15 @@ func createHostObj(d *schema.ResourceData, api *zabbix.API) (*zabbix.Host, error
 	return &host, nil
 }
 
-func resourceZabbixHostCreate(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixHostCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
+	api := client.api
 
-	host, err := createHostObj(d, api)
+	host, err := createHostObj(d, api, client.cache)
 
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	hosts := zabbix.Hosts{*host}
@@ -319,7 +276,7 @@ func resourceZabbixHostCreate(d *schema.ResourceData, meta interface{}) error {
 	err = api.HostsCreate(hosts)
 
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	log.Printf("[DEBUG] Created host id is %s", hosts[0].HostID)
@@ -330,15 +287,17 @@ func resourceZabbixHostCreate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceZabbixHostRead(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
+	api := client.api
 
 	log.Printf("[DEBUG] Will read host with id %s", d.Get("host_id").(string))
 
-	host, err := api.HostGetByID(d.Get("host_id").(string))
+	host, err := client.cache.getHost(api, getZabbixServerVersion(meta), d.Get("host_id").(string))
 
 	if err != nil {
-		return err
+		return diagFromErr(removeFromStateIfNotFound(d, "Host", err))
 	}
 
 	log.Printf("[DEBUG] Host name is %s", host.Name)
@@ -348,37 +307,30 @@ func resourceZabbixHostRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("monitored", host.Status == 0)
 
-	params := zabbix.Params{
-		"output": "extend",
-		"hostids": []string{
-			d.Id(),
-		},
-	}
-
-	templates, err := api.TemplatesGet(params)
+	templateIDs := make([]string, len(host.ParentTemplates))
 
-	if err != nil {
-		return err
+	for i, t := range host.ParentTemplates {
+		templateIDs[i] = t.TemplateID
 	}
 
-	templateNames := make([]string, len(templates))
+	templateNames, err := client.cache.getTemplateNames(api, templateIDs)
 
-	for i, t := range templates {
-		templateNames[i] = t.Host
+	if err != nil {
+		return diagFromErr(err)
 	}
 
 	d.Set("templates", templateNames)
 
-	groups, err := api.HostGroupsGet(params)
+	var groupIDs []string
 
-	if err != nil {
-		return err
+	for _, g := range append(host.Groups, host.HostGroups...) {
+		groupIDs = append(groupIDs, g.GroupID)
 	}
 
-	groupNames := make([]string, len(groups))
+	groupNames, err := client.cache.getHostGroupNames(api, groupIDs)
 
-	for i, g := range groups {
-		groupNames[i] = g.Name
+	if err != nil {
+		return diagFromErr(err)
 	}
 
 	d.Set("groups", groupNames)
@@ -386,13 +338,15 @@ func resourceZabbixHostRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceZabbixHostUpdate(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixHostUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
+	api := client.api
 
-	host, err := createHostObj(d, api)
+	host, err := createHostObj(d, api, client.cache)
 
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	host.HostID = d.Id()
@@ -404,9 +358,10 @@ func resourceZabbixHostUpdate(d *schema.ResourceData, meta interface{}) error {
 	hosts := zabbix.Hosts{*host}
 
 	err = api.HostsUpdate(hosts)
+	client.cache.invalidateHost(d.Id())
 
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	log.Printf("[DEBUG] Created host id is %s", hosts[0].HostID)
@@ -414,8 +369,10 @@ func resourceZabbixHostUpdate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceZabbixHostDelete(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixHostDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
 
-	return api.HostsDeleteByIds([]string{d.Id()})
+	defer client.cache.invalidateHost(d.Id())
+	return diagFromErr(client.api.HostsDeleteByIds([]string{d.Id()}))
 }
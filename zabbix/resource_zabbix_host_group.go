@@ -1,20 +1,20 @@
 package zabbix
 
 import (
+	"context"
 	"log"
-	"strings"
 
 	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceZabbixHostGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceZabbixHostGroupCreate,
-		Read:   resourceZabbixHostGroupRead,
-		Exists: resourceZabbixHostGroupExists,
-		Update: resourceZabbixHostGroupUpdate,
-		Delete: resourceZabbixHostGroupDelete,
+		CreateContext: resourceZabbixHostGroupCreate,
+		ReadContext:   resourceZabbixHostGroupRead,
+		UpdateContext: resourceZabbixHostGroupUpdate,
+		DeleteContext: resourceZabbixHostGroupDelete,
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
@@ -30,8 +30,10 @@ func resourceZabbixHostGroup() *schema.Resource {
 	}
 }
 
-func resourceZabbixHostGroupCreate(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixHostGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateHostGroups()
+	api := meta.(*providerClient).api
 
 	hostGroup := zabbix.HostGroup{
 		Name: d.Get("name").(string),
@@ -40,7 +42,7 @@ func resourceZabbixHostGroupCreate(d *schema.ResourceData, meta interface{}) err
 
 	err := api.HostGroupsCreate(groups)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	groupID := groups[0].GroupID
@@ -53,15 +55,16 @@ func resourceZabbixHostGroupCreate(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceZabbixHostGroupRead(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixHostGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
 
 	log.Printf("[DEBUG] Will read host group with id %s", d.Id())
 
 	group, err := api.HostGroupGetByID(d.Id())
 
 	if err != nil {
-		return err
+		return diagFromErr(removeFromStateIfNotFound(d, "Host group", err))
 	}
 
 	d.Set("name", group.Name)
@@ -69,33 +72,23 @@ func resourceZabbixHostGroupRead(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceZabbixHostGroupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	api := meta.(*zabbix.API)
-
-	_, err := api.HostGroupGetByID(d.Id())
-	if err != nil {
-		if strings.Contains(err.Error(), "Expected exactly one result") {
-			log.Printf("[DEBUG] Host group with id %s doesn't exist", d.Id())
-			return false, nil
-		}
-		return false, err
-	}
-	return true, nil
-}
-
-func resourceZabbixHostGroupUpdate(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixHostGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateHostGroups()
+	api := meta.(*providerClient).api
 
 	hostGroup := zabbix.HostGroup{
 		Name:    d.Get("name").(string),
 		GroupID: d.Id(),
 	}
 
-	return api.HostGroupsUpdate(zabbix.HostGroups{hostGroup})
+	return diagFromErr(api.HostGroupsUpdate(zabbix.HostGroups{hostGroup}))
 }
 
-func resourceZabbixHostGroupDelete(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixHostGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateHostGroups()
+	api := meta.(*providerClient).api
 
-	return api.HostGroupsDeleteByIds([]string{d.Id()})
+	return diagFromErr(api.HostGroupsDeleteByIds([]string{d.Id()}))
 }
@@ -33,7 +33,7 @@ func TestAccZabbixHostGroup_Basic(t *testing.T) {
 }
 
 func testAccCheckZabbixHostGroupDestroy(s *terraform.State) error {
-	api := testAccProvider.Meta().(*zabbix.API)
+	api := testAccProvider.Meta().(*providerClient).api
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "zabbix_host_group" {
@@ -70,7 +70,7 @@ func testAccCheckZabbixHostGroupExists(resource string, hostGroup *zabbix.HostGr
 			return fmt.Errorf("No record ID set")
 		}
 
-		api := testAccProvider.Meta().(*zabbix.API)
+		api := testAccProvider.Meta().(*providerClient).api
 		group, err := api.HostGroupGetByID(rs.Primary.ID)
 		if err != nil {
 			return err
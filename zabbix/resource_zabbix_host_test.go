@@ -40,7 +40,7 @@ func TestAccZabbixHost_Basic(t *testing.T) {
 }
 
 func testAccCheckZabbixHostDestroy(s *terraform.State) error {
-	api := testAccProvider.Meta().(*zabbix.API)
+	api := testAccProvider.Meta().(*providerClient).api
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "zabbix_host" {
@@ -87,7 +87,7 @@ func testAccCheckZabbixHostExists(resource string, host *zabbix.Host) resource.T
 			return fmt.Errorf("No record ID id set")
 		}
 
-		api := testAccProvider.Meta().(*zabbix.API)
+		api := testAccProvider.Meta().(*providerClient).api
 		getHost, err := api.HostGetByID(rs.Primary.ID)
 		if err != nil {
 			return err
@@ -99,7 +99,7 @@ func testAccCheckZabbixHostExists(resource string, host *zabbix.Host) resource.T
 
 func testAccCheckZabbixHostAttributes(host *zabbix.Host, want zabbix.Host, groupNames []string, templateNames []string) resource.TestCheckFunc {
 	return func(state *terraform.State) error {
-		api := testAccProvider.Meta().(*zabbix.API)
+		api := testAccProvider.Meta().(*providerClient).api
 
 		if host.Host != want.Host {
 			return fmt.Errorf("Got host name: %q, expected: %q", host.Host, want.Host)
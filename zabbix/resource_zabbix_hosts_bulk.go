@@ -0,0 +1,624 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// bulkHostObject represent a Zabbix host managed by zabbix_hosts_bulk
+// https://www.zabbix.com/documentation/current/manual/api/reference/host/object
+type bulkHostObject struct {
+	HostID     string              `json:"hostid,omitempty"`
+	Host       string              `json:"host"`
+	Name       string              `json:"name"`
+	Status     int                 `json:"status,string"`
+	Groups     zabbix.HostGroupIDs `json:"groups"`
+	Templates  *zabbix.TemplateIDs `json:"templates,omitempty"`
+	Interfaces []bulkHostInterface `json:"interfaces,omitempty"`
+	Macros     *[]bulkHostMacro    `json:"macros,omitempty"`
+}
+
+// bulkHostReadObject is a host returned by host.get
+type bulkHostReadObject struct {
+	HostID          string              `json:"hostid"`
+	Host            string              `json:"host"`
+	Name            string              `json:"name"`
+	Status          int                 `json:"status,string"`
+	Groups          zabbix.HostGroupIDs `json:"groups"`
+	HostGroups      zabbix.HostGroupIDs `json:"hostgroups"`
+	ParentTemplates zabbix.TemplateIDs  `json:"parentTemplates"`
+	Interfaces      []bulkHostInterface `json:"interfaces"`
+	Macros          []bulkHostReadMacro `json:"macros"`
+}
+
+type bulkHostInterface struct {
+	DNS   string `json:"dns"`
+	IP    string `json:"ip"`
+	Main  int    `json:"main,string"`
+	Port  string `json:"port"`
+	Type  int    `json:"type,string"`
+	UseIP int    `json:"useip,string"`
+}
+
+type bulkHostMacro struct {
+	Macro string `json:"macro"`
+	Value string `json:"value"`
+}
+
+type bulkHostReadMacro struct {
+	Macro string `json:"macro"`
+	Value string `json:"value"`
+	Type  string `json:"type"`
+}
+
+// bulkHostError is the error of one host of a batch
+type bulkHostError struct {
+	host      string
+	operation string
+	err       error
+}
+
+func resourceZabbixHostsBulk() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixHostsBulkCreate,
+		ReadContext:   resourceZabbixHostsBulkRead,
+		UpdateContext: resourceZabbixHostsBulkUpdate,
+		DeleteContext: resourceZabbixHostsBulkDelete,
+		Schema: map[string]*schema.Schema{
+			"host": &schema.Schema{
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Hosts managed by the resource.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Technical name of the host.",
+						},
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Visible name of the host, the technical name when empty.",
+						},
+						"monitored": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"groups": &schema.Schema{
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Required: true,
+						},
+						"templates": &schema.Schema{
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Optional: true,
+						},
+						"interface": &schema.Schema{
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Interfaces of the host, the host is recreated when they change.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"dns": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "",
+									},
+									"ip": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "",
+									},
+									"main": &schema.Schema{
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  true,
+									},
+									"port": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "10050",
+									},
+									"type": &schema.Schema{
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "agent",
+										ValidateFunc: validateHostInterfaceType,
+									},
+								},
+							},
+						},
+						"macros": &schema.Schema{
+							Type:         schema.TypeMap,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							Optional:     true,
+							Description:  "Text macros of the host, the keys are the macro names without the {$ and } delimiters.",
+							ValidateFunc: validateMacroNames,
+						},
+					},
+				},
+			},
+			"batch_size": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     100,
+				Description: "Number of hosts sent in each request.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 1 || v > 1000 {
+						errs = append(errs, fmt.Errorf("%q, must be between 1 and 1000 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"host_ids": &schema.Schema{
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "IDs of the hosts by technical name.",
+			},
+		},
+	}
+}
+
+func validateHostInterfaceType(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if _, ok := HostInterfaceTypes[v]; !ok {
+		errs = append(errs, fmt.Errorf("%q, must be one of agent, snmp, ipmi or jmx, got %s", key, v))
+	}
+	return
+}
+
+func validateMacroNames(val interface{}, key string) (warns []string, errs []error) {
+	for name := range val.(map[string]interface{}) {
+		_, nameErrs := validateMacroName(name, key)
+		errs = append(errs, nameErrs...)
+	}
+	return
+}
+
+func resourceZabbixHostsBulkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
+
+	var hostErrors []bulkHostError
+	hosts, buildErrors := createBulkHostObjects(d.Get("host").(*schema.Set).List(), client, true)
+	hostErrors = append(hostErrors, buildErrors...)
+
+	hostIDs, createErrors := sendBulkHosts(client, "host.create", hosts, d.Get("batch_size").(int))
+	hostErrors = append(hostErrors, createErrors...)
+
+	d.SetId(resource.UniqueId())
+	d.Set("host_ids", hostIDs)
+
+	return append(diagFromBulkHostErrors(hostErrors), resourceZabbixHostsBulkRead(ctx, d, meta)...)
+}
+
+func resourceZabbixHostsBulkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
+	api := client.api
+	zabbixVersion := getZabbixServerVersion(meta)
+
+	var ids []string
+	for _, id := range d.Get("host_ids").(map[string]interface{}) {
+		ids = append(ids, id.(string))
+	}
+	sort.Strings(ids)
+
+	batchSize := d.Get("batch_size").(int)
+	terraformHosts := make([]interface{}, 0, len(ids))
+	hostIDs := make(map[string]string, len(ids))
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		hosts, err := getBulkHosts(api, zabbixVersion, ids[start:end])
+		if err != nil {
+			return diagFromErr(err)
+		}
+		for _, host := range hosts {
+			terraformHost, err := flattenBulkHost(host, client)
+			if err != nil {
+				return diagFromErr(err)
+			}
+			terraformHosts = append(terraformHosts, terraformHost)
+			hostIDs[host.Host] = host.HostID
+		}
+	}
+	if len(hostIDs) < len(ids) {
+		// the missing hosts are created again by the next apply
+		log.Printf("[WARN] %d hosts of %s don't exist anymore", len(ids)-len(hostIDs), d.Id())
+	}
+
+	d.Set("host", terraformHosts)
+	d.Set("host_ids", hostIDs)
+	return nil
+}
+
+func resourceZabbixHostsBulkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
+	batchSize := d.Get("batch_size").(int)
+
+	o, n := d.GetChange("host")
+	oldHosts := indexBulkHosts(o.(*schema.Set))
+	newHosts := indexBulkHosts(n.(*schema.Set))
+	hostIDs := make(map[string]string)
+	for host, id := range d.Get("host_ids").(map[string]interface{}) {
+		hostIDs[host] = id.(string)
+	}
+
+	var deleted, created, updated []interface{}
+	recreated := make(map[string]bool)
+	for host := range oldHosts {
+		if _, ok := newHosts[host]; !ok {
+			deleted = append(deleted, oldHosts[host])
+		}
+	}
+	for host, newHost := range newHosts {
+		oldHost, ok := oldHosts[host]
+		switch {
+		case !ok || hostIDs[host] == "":
+			created = append(created, newHost)
+		case !oldHost["interface"].(*schema.Set).Equal(newHost["interface"]):
+			// the interfaces can't be updated, the same as zabbix_host
+			deleted = append(deleted, oldHost)
+			created = append(created, newHost)
+			recreated[host] = true
+		case n.(*schema.Set).F(oldHost) != n.(*schema.Set).F(newHost):
+			updated = append(updated, newHost)
+		}
+	}
+	log.Printf("[DEBUG] Hosts of %s to delete: %d, to create: %d, to update: %d", d.Id(), len(deleted), len(created), len(updated))
+
+	var hostErrors []bulkHostError
+
+	var deletedHosts []bulkHostObject
+	for _, h := range deleted {
+		host := h.(map[string]interface{})["host"].(string)
+		if id, ok := hostIDs[host]; ok {
+			deletedHosts = append(deletedHosts, bulkHostObject{HostID: id, Host: host})
+		}
+	}
+	deletedIDs, deleteErrors := sendBulkHosts(client, "host.delete", deletedHosts, batchSize)
+	hostErrors = append(hostErrors, deleteErrors...)
+	for host := range deletedIDs {
+		delete(hostIDs, host)
+	}
+	for i := 0; i < len(created); i++ {
+		// a host whose old version wasn't deleted can't be created again
+		if host := created[i].(map[string]interface{})["host"].(string); recreated[host] && hostIDs[host] != "" {
+			created = append(created[:i], created[i+1:]...)
+			i--
+		}
+	}
+
+	createdHosts, buildErrors := createBulkHostObjects(created, client, true)
+	hostErrors = append(hostErrors, buildErrors...)
+	createdIDs, createErrors := sendBulkHosts(client, "host.create", createdHosts, batchSize)
+	hostErrors = append(hostErrors, createErrors...)
+	for host, id := range createdIDs {
+		hostIDs[host] = id
+	}
+
+	updatedHosts, buildErrors := createBulkHostObjects(updated, client, false)
+	hostErrors = append(hostErrors, buildErrors...)
+	for i := range updatedHosts {
+		updatedHosts[i].HostID = hostIDs[updatedHosts[i].Host]
+	}
+	_, updateErrors := sendBulkHosts(client, "host.update", updatedHosts, batchSize)
+	hostErrors = append(hostErrors, updateErrors...)
+
+	d.Set("host_ids", hostIDs)
+	return append(diagFromBulkHostErrors(hostErrors), resourceZabbixHostsBulkRead(ctx, d, meta)...)
+}
+
+func resourceZabbixHostsBulkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
+
+	var hosts []bulkHostObject
+	for host, id := range d.Get("host_ids").(map[string]interface{}) {
+		hosts = append(hosts, bulkHostObject{HostID: id.(string), Host: host})
+	}
+	_, hostErrors := sendBulkHosts(client, "host.delete", hosts, d.Get("batch_size").(int))
+
+	diags := diagFromBulkHostErrors(hostErrors)
+	for i := range diags {
+		diags[i].Severity = diag.Error
+	}
+	return diags
+}
+
+func indexBulkHosts(hosts *schema.Set) map[string]map[string]interface{} {
+	index := make(map[string]map[string]interface{}, hosts.Len())
+	for _, h := range hosts.List() {
+		host := h.(map[string]interface{})
+		index[host["host"].(string)] = host
+	}
+	return index
+}
+
+// createBulkHostObjects returns the hosts to send and the errors of the hosts
+// whose groups or templates don't exist, the interfaces are only sent on create
+func createBulkHostObjects(terraformHosts []interface{}, client *providerClient, create bool) ([]bulkHostObject, []bulkHostError) {
+	var groupNames, templateNames []string
+	for _, h := range terraformHosts {
+		terraformHost := h.(map[string]interface{})
+		for _, name := range terraformHost["groups"].(*schema.Set).List() {
+			groupNames = append(groupNames, name.(string))
+		}
+		for _, name := range terraformHost["templates"].(*schema.Set).List() {
+			templateNames = append(templateNames, name.(string))
+		}
+	}
+
+	var hostErrors []bulkHostError
+	operation := "update"
+	if create {
+		operation = "create"
+	}
+	groupIDs, err := client.cache.getHostGroupIDs(client.api, groupNames)
+	if err != nil {
+		for _, h := range terraformHosts {
+			hostErrors = append(hostErrors, bulkHostError{host: h.(map[string]interface{})["host"].(string), operation: operation, err: err})
+		}
+		return nil, hostErrors
+	}
+	templateIDs, err := client.cache.getTemplateIDs(client.api, templateNames)
+	if err != nil {
+		for _, h := range terraformHosts {
+			hostErrors = append(hostErrors, bulkHostError{host: h.(map[string]interface{})["host"].(string), operation: operation, err: err})
+		}
+		return nil, hostErrors
+	}
+
+	hosts := make([]bulkHostObject, 0, len(terraformHosts))
+	for _, h := range terraformHosts {
+		host, err := createBulkHostObject(h.(map[string]interface{}), groupIDs, templateIDs, create)
+		if err != nil {
+			hostErrors = append(hostErrors, bulkHostError{host: h.(map[string]interface{})["host"].(string), operation: operation, err: err})
+			continue
+		}
+		hosts = append(hosts, *host)
+	}
+	return hosts, hostErrors
+}
+
+func createBulkHostObject(terraformHost map[string]interface{}, groupIDs, templateIDs map[string]string, create bool) (*bulkHostObject, error) {
+	host := bulkHostObject{
+		Host:   terraformHost["host"].(string),
+		Name:   terraformHost["name"].(string),
+		Status: 1,
+	}
+	if host.Name == "" {
+		host.Name = host.Host
+	}
+	if terraformHost["monitored"].(bool) {
+		host.Status = 0
+	}
+
+	for _, n := range terraformHost["groups"].(*schema.Set).List() {
+		groupID, ok := groupIDs[n.(string)]
+		if !ok {
+			return nil, fmt.Errorf("Host group %s doesnt exist in zabbix server", n)
+		}
+		host.Groups = append(host.Groups, zabbix.HostGroupID{GroupID: groupID})
+	}
+
+	templates := zabbix.TemplateIDs{}
+	for _, n := range terraformHost["templates"].(*schema.Set).List() {
+		templateID, ok := templateIDs[n.(string)]
+		if !ok {
+			return nil, fmt.Errorf("Template %s doesnt exist in zabbix server", n)
+		}
+		templates = append(templates, zabbix.TemplateID{TemplateID: templateID})
+	}
+	host.Templates = &templates
+
+	macros := []bulkHostMacro{}
+	for name, value := range terraformHost["macros"].(map[string]interface{}) {
+		macros = append(macros, bulkHostMacro{Macro: fmt.Sprintf("{$%s}", name), Value: value.(string)})
+	}
+	host.Macros = &macros
+
+	if create {
+		for _, i := range terraformHost["interface"].(*schema.Set).List() {
+			terraformInterface := i.(map[string]interface{})
+			hostInterface := bulkHostInterface{
+				DNS:   terraformInterface["dns"].(string),
+				IP:    terraformInterface["ip"].(string),
+				Port:  terraformInterface["port"].(string),
+				Type:  int(HostInterfaceTypes[terraformInterface["type"].(string)]),
+				UseIP: 1,
+			}
+			if hostInterface.IP == "" && hostInterface.DNS == "" {
+				return nil, fmt.Errorf("Atleast one of two dns or ip must be set")
+			}
+			if hostInterface.IP == "" {
+				hostInterface.UseIP = 0
+			}
+			if terraformInterface["main"].(bool) {
+				hostInterface.Main = 1
+			}
+			host.Interfaces = append(host.Interfaces, hostInterface)
+		}
+	}
+	return &host, nil
+}
+
+// sendBulkHosts calls method with batches of hosts and returns the IDs of the
+// hosts by technical name, the hosts of a failed batch are sent one by one so
+// that an invalid host doesn't fail the others
+func sendBulkHosts(client *providerClient, method string, hosts []bulkHostObject, batchSize int) (map[string]string, []bulkHostError) {
+	hostIDs := make(map[string]string, len(hosts))
+	var hostErrors []bulkHostError
+
+	for start := 0; start < len(hosts); start += batchSize {
+		end := start + batchSize
+		if end > len(hosts) {
+			end = len(hosts)
+		}
+		batch := hosts[start:end]
+
+		ids, err := callBulkHosts(client, method, batch)
+		if err == nil {
+			for i, host := range batch {
+				hostIDs[host.Host] = ids[i]
+			}
+			continue
+		}
+		if len(batch) == 1 {
+			hostErrors = append(hostErrors, bulkHostError{host: batch[0].Host, operation: method, err: err})
+			continue
+		}
+
+		log.Printf("[DEBUG] Batch %s of %d hosts failed, sending the hosts one by one: %s", method, len(batch), err)
+		for _, host := range batch {
+			ids, err := callBulkHosts(client, method, []bulkHostObject{host})
+			if err != nil {
+				hostErrors = append(hostErrors, bulkHostError{host: host.Host, operation: method, err: err})
+				continue
+			}
+			hostIDs[host.Host] = ids[0]
+		}
+	}
+	return hostIDs, hostErrors
+}
+
+func callBulkHosts(client *providerClient, method string, hosts []bulkHostObject) ([]string, error) {
+	var params interface{} = hosts
+	if method == "host.delete" {
+		ids := make([]string, len(hosts))
+		for i, host := range hosts {
+			ids[i] = host.HostID
+		}
+		params = ids
+	}
+
+	for _, host := range hosts {
+		if host.HostID != "" {
+			client.cache.invalidateHost(host.HostID)
+		}
+	}
+
+	response, err := client.api.CallWithError(method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := response.Result.(map[string]interface{})
+	ids := make([]string, len(hosts))
+	for i, id := range result["hostids"].([]interface{}) {
+		ids[i] = fmt.Sprint(id)
+	}
+	return ids, nil
+}
+
+func getBulkHosts(api *zabbix.API, zabbixVersion string, ids []string) ([]bulkHostReadObject, error) {
+	params := zabbix.Params{
+		"hostids":               ids,
+		"output":                []string{"hostid", "host", "name", "status"},
+		"selectParentTemplates": []string{"templateid"},
+		"selectInterfaces":      []string{"dns", "ip", "main", "port", "type", "useip"},
+		"selectMacros":          []string{"macro", "value", "type"},
+	}
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.2.0") {
+		params["selectHostGroups"] = []string{"groupid"}
+	} else {
+		params["selectGroups"] = []string{"groupid"}
+	}
+
+	var hosts []bulkHostReadObject
+	err := api.CallWithErrorParse("host.get", params, &hosts)
+	return hosts, err
+}
+
+func flattenBulkHost(host bulkHostReadObject, client *providerClient) (map[string]interface{}, error) {
+	var groupIDs []string
+	for _, group := range append(host.Groups, host.HostGroups...) {
+		groupIDs = append(groupIDs, group.GroupID)
+	}
+	groupNames, err := client.cache.getHostGroupNames(client.api, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var templateIDs []string
+	for _, template := range host.ParentTemplates {
+		templateIDs = append(templateIDs, template.TemplateID)
+	}
+	templateNames, err := client.cache.getTemplateNames(client.api, templateIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaceTypes := make(map[int]string, len(HostInterfaceTypes))
+	for name, id := range HostInterfaceTypes {
+		interfaceTypes[int(id)] = name
+	}
+	interfaces := make([]interface{}, len(host.Interfaces))
+	for i, hostInterface := range host.Interfaces {
+		interfaces[i] = map[string]interface{}{
+			"dns":  hostInterface.DNS,
+			"ip":   hostInterface.IP,
+			"main": hostInterface.Main == 1,
+			"port": hostInterface.Port,
+			"type": interfaceTypes[hostInterface.Type],
+		}
+	}
+
+	macros := make(map[string]interface{}, len(host.Macros))
+	for _, macro := range host.Macros {
+		// only the text macros are managed
+		if macro.Type != "" && macro.Type != "0" {
+			continue
+		}
+		name, err := getTerraformMacroName(macro.Macro)
+		if err != nil {
+			return nil, err
+		}
+		macros[name] = macro.Value
+	}
+
+	name := host.Name
+	if name == host.Host {
+		name = ""
+	}
+	return map[string]interface{}{
+		"host":      host.Host,
+		"name":      name,
+		"monitored": host.Status == 0,
+		"groups":    groupNames,
+		"templates": templateNames,
+		"interface": interfaces,
+		"macros":    macros,
+	}, nil
+}
+
+// diagFromBulkHostErrors returns a warning for each failed host, the other hosts
+// of the batch are applied and the failed hosts are planned again
+func diagFromBulkHostErrors(hostErrors []bulkHostError) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, hostError := range hostErrors {
+		diagnostic := diagFromErr(hostError.err)[0]
+		diagnostic.Severity = diag.Warning
+		diagnostic.Summary = fmt.Sprintf("Host %s failed on %s: %s", hostError.host, hostError.operation, diagnostic.Summary)
+		diags = append(diags, diagnostic)
+	}
+	return diags
+}
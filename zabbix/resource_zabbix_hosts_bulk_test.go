@@ -0,0 +1,143 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccZabbixHostsBulk_Basic(t *testing.T) {
+	randName := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixHostsBulkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixHostsBulkConfig(randName, []string{"a", "b", "c"}, "127.0.0.1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_hosts_bulk.zabbix", "host.#", "3"),
+					resource.TestCheckResourceAttr("zabbix_hosts_bulk.zabbix", "host_ids.%", "3"),
+					resource.TestCheckResourceAttrSet("zabbix_hosts_bulk.zabbix", fmt.Sprintf("host_ids.host_a_%s", randName)),
+				),
+			},
+			{
+				Config: testAccZabbixHostsBulkConfig(randName, []string{"b", "c", "d"}, "127.0.0.2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_hosts_bulk.zabbix", "host.#", "3"),
+					resource.TestCheckResourceAttr("zabbix_hosts_bulk.zabbix", "host_ids.%", "3"),
+					resource.TestCheckNoResourceAttr("zabbix_hosts_bulk.zabbix", fmt.Sprintf("host_ids.host_a_%s", randName)),
+					resource.TestCheckResourceAttrSet("zabbix_hosts_bulk.zabbix", fmt.Sprintf("host_ids.host_d_%s", randName)),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckZabbixHostsBulkDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(*providerClient).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "zabbix_hosts_bulk" {
+			continue
+		}
+
+		var ids []string
+		for key, id := range rs.Primary.Attributes {
+			if strings.HasPrefix(key, "host_ids.") && key != "host_ids.%" {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		hosts, err := api.HostsGet(zabbix.Params{"hostids": ids})
+		if err != nil {
+			return err
+		}
+		if len(hosts) != 0 {
+			return fmt.Errorf("%d hosts still exist", len(hosts))
+		}
+	}
+	return nil
+}
+
+func testAccZabbixHostsBulkConfig(randName string, hosts []string, ip string) string {
+	var config string
+	for _, host := range hosts {
+		config += fmt.Sprintf(`
+			host {
+				host = "host_%s_%s"
+				groups = [zabbix_host_group.zabbix.name]
+				interface {
+					ip = "%s"
+				}
+				macros = {
+					"ENVIRONMENT" = "test"
+				}
+			}`, host, randName, ip)
+	}
+	return fmt.Sprintf(`
+		resource "zabbix_host_group" "zabbix" {
+			name = "host_group_%s"
+		}
+
+		resource "zabbix_hosts_bulk" "zabbix" {
+			batch_size = 2
+			%s
+		}`, randName, config,
+	)
+}
+
+func TestSendBulkHosts(t *testing.T) {
+	var requests []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Params []bulkHostObject `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		requests = append(requests, len(request.Params))
+		for _, host := range request.Params {
+			if host.Host == "invalid" {
+				w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params.","data":"Host \"invalid\" already exists."},"id":1}`))
+				return
+			}
+		}
+		ids := make([]string, len(request.Params))
+		for i, host := range request.Params {
+			ids[i] = "id_" + host.Host
+		}
+		result, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "result": map[string]interface{}{"hostids": ids}, "id": 1})
+		w.Write(result)
+	}))
+	defer server.Close()
+
+	client := &providerClient{api: zabbix.NewAPI(server.URL), cache: newLookupCache()}
+	hosts := []bulkHostObject{{Host: "a"}, {Host: "b"}, {Host: "invalid"}, {Host: "c"}, {Host: "d"}}
+	ids, hostErrors := sendBulkHosts(client, "host.create", hosts, 2)
+
+	if len(ids) != 4 || ids["a"] != "id_a" || ids["c"] != "id_c" || ids["d"] != "id_d" {
+		t.Fatalf("expected the IDs of the valid hosts, got %v", ids)
+	}
+	if len(hostErrors) != 1 || hostErrors[0].host != "invalid" {
+		t.Fatalf("expected an error for the invalid host only, got %v", hostErrors)
+	}
+	// the batch with the invalid host is sent again one host at a time
+	if fmt.Sprint(requests) != "[2 2 1 1 1]" {
+		t.Fatalf("unexpected requests %v", requests)
+	}
+
+	diags := diagFromBulkHostErrors(hostErrors)
+	if len(diags) != 1 || diags[0].Summary != "Host invalid failed on host.create: Zabbix API error -32602: Invalid params." {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+}
@@ -1,28 +1,45 @@
 package zabbix
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// itemObject extends zabbix.Item with the properties unknown to the API client
+type itemObject struct {
+	zabbix.Item
+	ValueMapID string `json:"valuemapid"`
+}
+
 func resourceZabbixItem() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceZabbixItemCreate,
-		Read:   resourceZabbixItemRead,
-		Exists: resourceZabbixItemExists,
-		Update: resourceZabbixItemUpdate,
-		Delete: resourceZabbixItemDelete,
+		CreateContext: resourceZabbixItemCreate,
+		ReadContext:   resourceZabbixItemRead,
+		UpdateContext: resourceZabbixItemUpdate,
+		DeleteContext: resourceZabbixItemDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffVersions("zabbix_item", "",
+				versionedAttribute{attribute: "data_type", removedVersion: "3.4.0"},
+				versionedAttribute{attribute: "delta", removedVersion: "3.4.0"},
+			),
+			customizeDiffDays("history", "trends"),
+			customizeDiffDelays("delay"),
+		),
 		Schema: map[string]*schema.Schema{
 			"delay": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateDelay,
+				DiffSuppressFunc: suppressEquivalentDelays,
 			},
 			"host_id": &schema.Schema{
 				Type:        schema.TypeString,
@@ -101,27 +118,45 @@ func resourceZabbixItem() *schema.Resource {
 				Default:     "",
 			},
 			"history": &schema.Schema{
-				Type:        schema.TypeString,
-				Computed:    true,
-				Optional:    true,
-				Description: "Number of days to keep item's history data. From 3.4 version, string is required instead of integer. Default: 90 (90d for 3.4+).",
+				Type:             schema.TypeString,
+				Computed:         true,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentStoragePeriods,
+				ValidateFunc:     validateStoragePeriod,
+				Description:      "Number of days to keep item's history data. From 3.4 version, string is required instead of integer. Default: 90 (90d for 3.4+).",
 			},
 			"trends": &schema.Schema{
-				Type:        schema.TypeString,
-				Computed:    true,
-				Optional:    true,
-				Description: "Number of days to keep item's trends data. From 3.4 version, string is required instead of interger. Default: 365 (365d for 3.4+).",
+				Type:             schema.TypeString,
+				Computed:         true,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentStoragePeriods,
+				ValidateFunc:     validateStoragePeriod,
+				Description:      "Number of days to keep item's trends data. From 3.4 version, string is required instead of interger. Default: 365 (365d for 3.4+).",
 			},
 			"trapper_host": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Allowed hosts. Used only by trapper items.",
 			},
+			"valuemap": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID or name of the value map applied to the item.",
+			},
+			"valuemap_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the value map applied to the item.",
+			},
 		},
 	}
 }
 
-func createItemObject(d *schema.ResourceData) *zabbix.Item {
+func createItemObject(d *schema.ResourceData, api *zabbix.API, cache *lookupCache, zabbixVersion string) (*itemObject, error) {
+	valueMapID, err := getValueMapID(api, cache, zabbixVersion, d.Get("host_id").(string), d.Get("valuemap").(string))
+	if err != nil {
+		return nil, err
+	}
 
 	item := zabbix.Item{
 		Delay:        d.Get("delay").(string),
@@ -134,26 +169,33 @@ func createItemObject(d *schema.ResourceData) *zabbix.Item {
 		DataType:     zabbix.DataType(d.Get("data_type").(int)),
 		Delta:        zabbix.DeltaType(d.Get("delta").(int)),
 		Description:  d.Get("description").(string),
-		History:      d.Get("history").(string),
-		Trends:       d.Get("trends").(string),
+		History:      normalizeDays(d.Get("history").(string), zabbixVersion),
+		Trends:       normalizeDays(d.Get("trends").(string), zabbixVersion),
 		TrapperHosts: d.Get("trapper_host").(string),
 	}
 
-	return &item
+	return &itemObject{Item: item, ValueMapID: valueMapID}, nil
 }
 
-func resourceZabbixItemCreate(d *schema.ResourceData, meta interface{}) error {
-	item := createItemObject(d)
+func resourceZabbixItemCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	item, err := createItemObject(d, api, meta.(*providerClient).cache, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
 
-	return createRetry(d, meta, createItem, *item, resourceZabbixItemRead)
+	return createRetry(ctx, d, meta, createItem, *item, resourceZabbixItemRead)
 }
 
-func resourceZabbixItemRead(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixItemRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
 
-	item, err := api.ItemGetByID(d.Id())
+	item, err := getItemByID(d.Id(), api)
 	if err != nil {
-		return err
+		return diagFromErr(removeFromStateIfNotFound(d, "Item", err))
 	}
 
 	d.Set("delay", item.Delay)
@@ -169,37 +211,34 @@ func resourceZabbixItemRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("history", item.History)
 	d.Set("trends", item.Trends)
 	d.Set("trapper_host", item.TrapperHosts)
+	d.Set("valuemap_id", item.ValueMapID)
+	d.Set("valuemap", createTerraformValueMap(d, api, meta.(*providerClient).cache, getZabbixServerVersion(meta), item.ValueMapID))
 
 	log.Printf("[DEBUG] Item name is %s\n", item.Name)
 	return nil
 }
 
-func resourceZabbixItemExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	api := meta.(*zabbix.API)
+func resourceZabbixItemUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateItems()
+	api := meta.(*providerClient).api
 
-	_, err := api.ItemGetByID(d.Id())
+	item, err := createItemObject(d, api, meta.(*providerClient).cache, getZabbixServerVersion(meta))
 	if err != nil {
-		if strings.Contains(err.Error(), "Expected exactly one result") {
-			log.Printf("[DEBUG] Item with id %s doesn't exist", d.Id())
-			return false, nil
-		}
-		return false, err
+		return diagFromErr(err)
 	}
-	return true, nil
-}
-
-func resourceZabbixItemUpdate(d *schema.ResourceData, meta interface{}) error {
-	item := createItemObject(d)
 
 	item.ItemID = d.Id()
-	return createRetry(d, meta, updateItem, *item, resourceZabbixItemRead)
+	return createRetry(ctx, d, meta, updateItem, *item, resourceZabbixItemRead)
 
 }
 
-func resourceZabbixItemDelete(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixItemDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateItems()
+	api := meta.(*providerClient).api
 
-	return deleteRetry(d.Id(), getItemParentID, api.ItemsDeleteIDs, api)
+	return diagFromErr(deleteRetry(ctx, d.Id(), getItemParentID, api.ItemsDeleteIDs, api))
 }
 
 func getItemParentID(api *zabbix.API, id string) (string, error) {
@@ -220,24 +259,41 @@ func getItemParentID(api *zabbix.API, id string) (string, error) {
 	return items[0].ItemParent[0].HostID, nil
 }
 
-func createItem(item interface{}, api *zabbix.API) (id string, err error) {
-	items := zabbix.Items{item.(zabbix.Item)}
+func getItemByID(id string, api *zabbix.API) (*itemObject, error) {
+	var items []itemObject
 
-	err = api.ItemsCreate(items)
+	err := api.CallWithErrorParse("item.get", zabbix.Params{
+		"itemids": id,
+		"output":  "extend",
+	}, &items)
+	if err != nil {
+		return nil, err
+	}
+	err = expectOneResult("Item", id, len(items))
+	if err != nil {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+func createItem(item interface{}, api *zabbix.API) (id string, err error) {
+	response, err := api.CallWithError("item.create", []itemObject{item.(itemObject)})
 	if err != nil {
 		return
 	}
-	id = items[0].ItemID
+
+	result := response.Result.(map[string]interface{})
+	id = result["itemids"].([]interface{})[0].(string)
 	return
 }
 
 func updateItem(item interface{}, api *zabbix.API) (id string, err error) {
-	items := zabbix.Items{item.(zabbix.Item)}
+	zabbixItem := item.(itemObject)
 
-	err = api.ItemsUpdate(items)
+	_, err = api.CallWithError("item.update", []itemObject{zabbixItem})
 	if err != nil {
 		return
 	}
-	id = items[0].ItemID
+	id = zabbixItem.ItemID
 	return
 }
@@ -1,28 +1,39 @@
 package zabbix
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceZabbixItemPrototype() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceZabbixItemPrototypeCreate,
-		Read:   resourceZabbixItemPrototypeRead,
-		Exists: resourceZabbixItemPrototypeExist,
-		Update: resourceZabbixItemPrototypeUpdate,
-		Delete: resourceZabbixItemPrototypeDelete,
+		CreateContext: resourceZabbixItemPrototypeCreate,
+		ReadContext:   resourceZabbixItemPrototypeRead,
+		UpdateContext: resourceZabbixItemPrototypeUpdate,
+		DeleteContext: resourceZabbixItemPrototypeDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffVersions("zabbix_item_prototype", "",
+				versionedAttribute{attribute: "data_type", removedVersion: "3.4.0"},
+				versionedAttribute{attribute: "delta", removedVersion: "3.4.0"},
+			),
+			customizeDiffDays("history", "trends"),
+			customizeDiffDelays("delay"),
+		),
 		Schema: map[string]*schema.Schema{
 			"delay": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateDelay,
+				DiffSuppressFunc: suppressEquivalentDelays,
 			},
 			"host_id": &schema.Schema{
 				Type:        schema.TypeString,
@@ -105,16 +116,20 @@ func resourceZabbixItemPrototype() *schema.Resource {
 				Default:     "",
 			},
 			"history": &schema.Schema{
-				Type:        schema.TypeString,
-				Computed:    true,
-				Optional:    true,
-				Description: "Number of days to keep item's history data. Default: 90.",
+				Type:             schema.TypeString,
+				Computed:         true,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentStoragePeriods,
+				ValidateFunc:     validateStoragePeriod,
+				Description:      "Number of days to keep item's history data. Default: 90.",
 			},
 			"trends": &schema.Schema{
-				Type:        schema.TypeString,
-				Computed:    true,
-				Optional:    true,
-				Description: "Number of days to keep item's trends data. Default: 365.",
+				Type:             schema.TypeString,
+				Computed:         true,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentStoragePeriods,
+				ValidateFunc:     validateStoragePeriod,
+				Description:      "Number of days to keep item's trends data. Default: 365.",
 			},
 			"trapper_host": &schema.Schema{
 				Type:        schema.TypeString,
@@ -127,11 +142,25 @@ func resourceZabbixItemPrototype() *schema.Resource {
 				Default:     "0",
 				Description: "Status of the item.",
 			},
+			"valuemap": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID or name of the value map applied to the item prototype.",
+			},
+			"valuemap_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the value map applied to the item prototype.",
+			},
 		},
 	}
 }
 
-func createItemPrototypeObject(d *schema.ResourceData, api *zabbix.API) (*zabbix.ItemPrototype, error) {
+func createItemPrototypeObject(d *schema.ResourceData, api *zabbix.API, cache *lookupCache, zabbixVersion string) (*zabbix.ItemPrototype, error) {
+	valueMapID, err := getValueMapID(api, cache, zabbixVersion, d.Get("host_id").(string), d.Get("valuemap").(string))
+	if err != nil {
+		return nil, err
+	}
 
 	item := zabbix.ItemPrototype{
 		Delay:        d.Get("delay").(string),
@@ -145,27 +174,30 @@ func createItemPrototypeObject(d *schema.ResourceData, api *zabbix.API) (*zabbix
 		DataType:     zabbix.DataType(d.Get("data_type").(int)),
 		Delta:        zabbix.DeltaType(d.Get("delta").(int)),
 		Description:  d.Get("description").(string),
-		History:      d.Get("history").(string),
-		Trends:       d.Get("trends").(string),
+		History:      normalizeDays(d.Get("history").(string), zabbixVersion),
+		Trends:       normalizeDays(d.Get("trends").(string), zabbixVersion),
 		TrapperHosts: d.Get("trapper_host").(string),
 		Status:       d.Get("status").(int),
+		Valuemapid:   valueMapID,
 	}
 	return &item, nil
 }
 
-func resourceZabbixItemPrototypeCreate(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixItemPrototypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
 
-	item, err := createItemPrototypeObject(d, api)
+	item, err := createItemPrototypeObject(d, api, meta.(*providerClient).cache, getZabbixServerVersion(meta))
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
-	return createRetry(d, meta, createItemPrototype, *item, resourceZabbixItemPrototypeRead)
+	return createRetry(ctx, d, meta, createItemPrototype, *item, resourceZabbixItemPrototypeRead)
 }
 
-func resourceZabbixItemPrototypeRead(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixItemPrototypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
 
 	items, err := api.ItemPrototypesGet(zabbix.Params{
 		"itemids":             d.Id(),
@@ -173,10 +205,11 @@ func resourceZabbixItemPrototypeRead(d *schema.ResourceData, meta interface{}) e
 		"selectDiscoveryRule": "extend",
 	})
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	if len(items) != 1 {
-		return fmt.Errorf("Expected one item prototype and got : %d ", len(items))
+	err = expectOneResult("Item prototype", d.Id(), len(items))
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "Item prototype", err))
 	}
 	item := items[0]
 
@@ -195,42 +228,34 @@ func resourceZabbixItemPrototypeRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("trends", item.Trends)
 	d.Set("trapper_host", item.TrapperHosts)
 	d.Set("status", item.Status)
+	d.Set("valuemap_id", item.Valuemapid)
+	d.Set("valuemap", createTerraformValueMap(d, api, meta.(*providerClient).cache, getZabbixServerVersion(meta), item.Valuemapid))
 
 	log.Printf("[DEBUG] Item prototype name is %s\n", item.Name)
 	return nil
 }
 
-func resourceZabbixItemPrototypeExist(d *schema.ResourceData, meta interface{}) (bool, error) {
-	api := meta.(*zabbix.API)
-
-	_, err := api.ItemPrototypeGetByID(d.Id())
-	if err != nil {
-		if strings.Contains(err.Error(), "Expected exactly one result") {
-			log.Printf("[DEBUG] Item prototype with id %s doesn't exist", d.Id())
-			return false, nil
-		}
-		return false, err
-	}
-	return true, nil
-}
-
-func resourceZabbixItemPrototypeUpdate(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixItemPrototypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateItems()
+	api := meta.(*providerClient).api
 
-	item, err := createItemPrototypeObject(d, api)
+	item, err := createItemPrototypeObject(d, api, meta.(*providerClient).cache, getZabbixServerVersion(meta))
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	item.ItemID = d.Id()
 	log.Printf("[DEBUG] Update item prototype %#v", item)
-	return createRetry(d, meta, updateItemPrototype, *item, resourceZabbixItemPrototypeRead)
+	return createRetry(ctx, d, meta, updateItemPrototype, *item, resourceZabbixItemPrototypeRead)
 }
 
-func resourceZabbixItemPrototypeDelete(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixItemPrototypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateItems()
+	api := meta.(*providerClient).api
 
-	return deleteRetry(d.Id(), getItemPrototypeParentID, api.ItemPrototypesDeleteIDs, api)
+	return diagFromErr(deleteRetry(ctx, d.Id(), getItemPrototypeParentID, api.ItemPrototypesDeleteIDs, api))
 }
 
 func getItemPrototypeParentID(api *zabbix.API, id string) (string, error) {
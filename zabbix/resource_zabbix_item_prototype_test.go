@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/claranet/go-zabbix-api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -47,7 +46,7 @@ func TestAccZabbixItemPrototype_Basic(t *testing.T) {
 }
 
 func testAccCheckZabbixItemPrototypeDestroy(s *terraform.State) error {
-	api := testAccProvider.Meta().(*zabbix.API)
+	api := testAccProvider.Meta().(*providerClient).api
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "zabbix_item_prototype" {
@@ -69,13 +68,13 @@ func testAccCheckZabbixItemPrototypeDestroy(s *terraform.State) error {
 
 func testAccZabbixItemPrototypeConfig(groupName, templateName string) string {
 	return fmt.Sprintf(`
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name for template_test %s"
 	  	}
 
@@ -110,13 +109,13 @@ func testAccZabbixItemPrototypeConfig(groupName, templateName string) string {
 
 func testAccZabbixItemPrototypeUpdateConfig(groupName, templateName string) string {
 	return fmt.Sprintf(`
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name for template test %s"
 	  	}
 
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/claranet/go-zabbix-api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -61,13 +60,13 @@ func testAccZabbixItemConfig(groupName, templateName, itemName string) string {
 	return fmt.Sprintf(`
 		data "zabbix_server" "test" {}
 
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "%s"
 		}
 
 		resource "zabbix_template" "my_zbx_template" {
 			host = "%s"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name %s"
 			description = "description for template %s"
 	  	}
@@ -88,13 +87,13 @@ func testAccZabbixItemUpdate(groupName, templateName, itemName string) string {
 	return fmt.Sprintf(`
 		data "zabbix_server" "test" {}
 
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "%s"
 		}
 
 		resource "zabbix_template" "my_zbx_template" {
 			host = "%s"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name %s"
 			description = "description for template %s"
 	  	}
@@ -125,7 +124,7 @@ func testAccZabbixItemExists(resource string) resource.TestCheckFunc {
 }
 
 func testAccCheckZabbixItemDestroy(s *terraform.State) error {
-	api := testAccProvider.Meta().(*zabbix.API)
+	api := testAccProvider.Meta().(*providerClient).api
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "zabbix_item" {
@@ -1,28 +1,156 @@
 package zabbix
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"log"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// Item types added after Zabbix 3.2, unknown to the API client
+const (
+	itemTypeDependent zabbix.ItemType = 18
+	itemTypeHTTPAgent zabbix.ItemType = 19
+	itemTypeSNMPAgent zabbix.ItemType = 20
+)
+
+// discoveryRule is the discovery rule object exchanged with the API. It extends
+// zabbix.LLDRule with the properties introduced after Zabbix 3.2.
+type discoveryRule struct {
+	zabbix.LLDRule
+	Description     string               `json:"description"`
+	Status          int                  `json:"status,string"`
+	MasterItemID    string               `json:"master_itemid,omitempty"`
+	URL             string               `json:"url,omitempty"`
+	RequestMethod   string               `json:"request_method,omitempty"`
+	Timeout         string               `json:"timeout,omitempty"`
+	StatusCodes     string               `json:"status_codes,omitempty"`
+	FollowRedirects string               `json:"follow_redirects,omitempty"`
+	Headers         *lldRuleHeaders      `json:"headers,omitempty"`
+	QueryFields     *[]map[string]string `json:"query_fields,omitempty"`
+	Posts           string               `json:"posts,omitempty"`
+	PostType        string               `json:"post_type,omitempty"`
+	HTTPProxy       string               `json:"http_proxy,omitempty"`
+	LLDMacroPaths   *[]lldMacroPath      `json:"lld_macro_paths,omitempty"`
+	Preprocessing   *[]preprocessingStep `json:"preprocessing,omitempty"`
+	Overrides       *[]lldRuleOverride   `json:"overrides,omitempty"`
+}
+
+// lldRuleHeaders is returned as an empty JSON array by the API when no header is set
+type lldRuleHeaders map[string]string
+
+func (h *lldRuleHeaders) UnmarshalJSON(b []byte) error {
+	if strings.TrimSpace(string(b)) == "[]" {
+		*h = nil
+		return nil
+	}
+	return json.Unmarshal(b, (*map[string]string)(h))
+}
+
+type lldMacroPath struct {
+	LLDMacro string `json:"lld_macro"`
+	Path     string `json:"path"`
+}
+
+type preprocessingStep struct {
+	Type               int    `json:"type,string"`
+	Params             string `json:"params"`
+	ErrorHandler       int    `json:"error_handler,string"`
+	ErrorHandlerParams string `json:"error_handler_params"`
+}
+
+type lldRuleOverride struct {
+	Name       string                     `json:"name"`
+	Step       int                        `json:"step,string"`
+	Stop       int                        `json:"stop,string"`
+	Filter     *zabbix.LLDRuleFilter      `json:"filter,omitempty"`
+	Operations []lldRuleOverrideOperation `json:"operations"`
+}
+
+type lldRuleOverrideOperation struct {
+	OperationObject int             `json:"operationobject,string"`
+	Operator        int             `json:"operator,string"`
+	Value           string          `json:"value"`
+	OpStatus        *lldOpStatus    `json:"opstatus,omitempty"`
+	OpDiscover      *lldOpDiscover  `json:"opdiscover,omitempty"`
+	OpPeriod        *lldOpPeriod    `json:"opperiod,omitempty"`
+	OpHistory       *lldOpHistory   `json:"ophistory,omitempty"`
+	OpTrends        *lldOpTrends    `json:"optrends,omitempty"`
+	OpSeverity      *lldOpSeverity  `json:"opseverity,omitempty"`
+	OpInventory     *lldOpInventory `json:"opinventory,omitempty"`
+	OpTag           []lldOpTag      `json:"optag,omitempty"`
+	OpTemplate      []lldOpTemplate `json:"optemplate,omitempty"`
+}
+
+type lldOpStatus struct {
+	Status int `json:"status,string"`
+}
+
+type lldOpDiscover struct {
+	Discover int `json:"discover,string"`
+}
+
+type lldOpPeriod struct {
+	Delay string `json:"delay"`
+}
+
+type lldOpHistory struct {
+	History string `json:"history"`
+}
+
+type lldOpTrends struct {
+	Trends string `json:"trends"`
+}
+
+type lldOpSeverity struct {
+	Severity int `json:"severity,string"`
+}
+
+type lldOpInventory struct {
+	InventoryMode int `json:"inventory_mode,string"`
+}
+
+type lldOpTag struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+type lldOpTemplate struct {
+	TemplateID string `json:"templateid"`
+}
+
 func resourceZabbixLLDRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceZabbixLLDRuleCreate,
-		Read:   resourceZabbixLLDRuleRead,
-		Exists: resourceZabbixLLDRuleExists,
-		Update: resourceZabbixLLDRuleUpdate,
-		Delete: resourceZabbixLLDRuleDelete,
+		CreateContext: resourceZabbixLLDRuleCreate,
+		ReadContext:   resourceZabbixLLDRuleRead,
+		UpdateContext: resourceZabbixLLDRuleUpdate,
+		DeleteContext: resourceZabbixLLDRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffVersions("zabbix_lld_rule", "",
+				versionedAttribute{attribute: "lld_macro_path", minVersion: "4.2.0"},
+				versionedAttribute{attribute: "preprocessing", minVersion: "4.2.0"},
+				versionedAttribute{attribute: "override", minVersion: "5.0.0"},
+			),
+			customizeDiffDelays("delay"),
+		),
 		Schema: map[string]*schema.Schema{
 			"delay": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateDelay,
+				DiffSuppressFunc: suppressEquivalentDelays,
 			},
 			"host_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -30,7 +158,8 @@ func resourceZabbixLLDRule() *schema.Resource {
 			},
 			"interface_id": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Default:  "0",
 			},
 			"key": &schema.Schema{Type: schema.TypeString,
 				Required: true,
@@ -43,11 +172,147 @@ func resourceZabbixLLDRule() *schema.Resource {
 				Type:     schema.TypeInt,
 				Required: true,
 			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Description of the LLD rule.",
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 1 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 1 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"lifetime": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Time period after which items that are no longer discovered will be deleted.",
+			},
 			"filter": &schema.Schema{
 				Type:     schema.TypeSet,
 				MaxItems: 1,
 				Elem:     schemaLLDRuleFilter(),
-				Required: true,
+				Optional: true,
+			},
+			"lld_macro_path": &schema.Schema{
+				Type:     schema.TypeSet,
+				Elem:     schemaLLDRuleMacroPath(),
+				Optional: true,
+			},
+			"preprocessing": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     schemaPreprocessingStep(),
+				Optional: true,
+			},
+			"override": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     schemaLLDRuleOverride(),
+				Optional: true,
+			},
+			"master_item_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Master item ID. Used only by dependent LLD rules.",
+			},
+			"snmp_oid": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SNMP OID. Used only by SNMP LLD rules.",
+			},
+			"params": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Additional parameters depending on the type of the LLD rule.",
+			},
+			"username": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"password": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"trapper_host": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Allowed hosts. Used only by trapper LLD rules.",
+			},
+			"url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL string. Used only by HTTP agent LLD rules.",
+			},
+			"request_method": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 3 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 3 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"timeout": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"status_codes": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"follow_redirects": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"headers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"query_field": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Ordered list of query parameters.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+						},
+					},
+				},
+			},
+			"posts": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"post_type": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"http_proxy": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 		},
 	}
@@ -90,31 +355,213 @@ func schemaLLDRuleFilterCondition() *schema.Resource {
 				Optional: true,
 				Default:  "8",
 			},
+			"formula_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "ID used to reference the condition from a custom expression.",
+			},
 		},
 	}
 }
 
-func resourceZabbixLLDRuleCreate(d *schema.ResourceData, meta interface{}) error {
-	rule := createLLDRuleObject(d)
+func schemaLLDRuleMacroPath() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"lld_macro": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"path": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func schemaPreprocessingStep() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"params": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"error_handler": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"error_handler_params": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+		},
+	}
+}
 
-	return createRetry(d, meta, createLLDRule, rule, resourceZabbixLLDRuleRead)
+func schemaLLDRuleOverride() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"stop": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Stop processing next overrides if this one matches.",
+			},
+			"filter": &schema.Schema{
+				Type:     schema.TypeSet,
+				MaxItems: 1,
+				Elem:     schemaLLDRuleFilter(),
+				Optional: true,
+			},
+			"operation": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     schemaLLDRuleOverrideOperation(),
+				Optional: true,
+			},
+		},
+	}
 }
 
-func resourceZabbixLLDRuleRead(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func schemaLLDRuleOverrideOperation() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"object": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 3 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 3 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"operator": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"value": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"status": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntInSlice([]int{0, 1}),
+			},
+			"discover": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntInSlice([]int{0, 1}),
+			},
+			"delay": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateDelay,
+				DiffSuppressFunc: suppressEquivalentDelays,
+			},
+			"history": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateStoragePeriod,
+				DiffSuppressFunc: suppressEquivalentStoragePeriods,
+			},
+			"trends": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateStoragePeriod,
+				DiffSuppressFunc: suppressEquivalentStoragePeriods,
+			},
+			"severity": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 5),
+			},
+			"inventory_mode": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntInSlice([]int{-1, 0, 1}),
+			},
+			"tag": &schema.Schema{
+				Type:     schema.TypeSet,
+				Elem:     schemaLLDRuleOverrideTag(),
+				Optional: true,
+			},
+			"template_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+		},
+	}
+}
+
+func schemaLLDRuleOverrideTag() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tag": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"value": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+		},
+	}
+}
+
+func resourceZabbixLLDRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	rule, err := createLLDRuleObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return createRetry(ctx, d, meta, createLLDRule, *rule, resourceZabbixLLDRuleRead)
+}
+
+func resourceZabbixLLDRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+	zabbixVersion := getZabbixServerVersion(meta)
 	params := zabbix.Params{
 		"itemids":      d.Id(),
 		"output":       "extend",
 		"selectFilter": "extend",
 		"inherited":    false,
 	}
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "4.2.0") {
+		params["selectLLDMacroPaths"] = "extend"
+		params["selectPreprocessing"] = "extend"
+	}
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.0.0") {
+		params["selectOverrides"] = "extend"
+	}
 
-	lldRules, err := api.DiscoveryRulesGet(params)
+	var lldRules []discoveryRule
+	err := api.CallWithErrorParse("discoveryrule.get", params, &lldRules)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	if len(lldRules) != 1 {
-		return fmt.Errorf("Expected one low level discovery rule with id %s and got %d rules", d.Id(), len(lldRules))
+	err = expectOneResult("LLD rule", d.Id(), len(lldRules))
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "LLD rule", err))
 	}
 	lldRule := lldRules[0]
 
@@ -124,104 +571,431 @@ func resourceZabbixLLDRuleRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("key", lldRule.Key)
 	d.Set("name", lldRule.Name)
 	d.Set("type", lldRule.Type)
+	d.Set("description", lldRule.Description)
+	d.Set("status", lldRule.Status)
+	d.Set("lifetime", lldRule.LifeTime)
+	d.Set("master_item_id", lldRule.MasterItemID)
+	d.Set("snmp_oid", lldRule.SnmpOid)
+	d.Set("params", lldRule.Params)
+	d.Set("username", lldRule.Username)
+	d.Set("trapper_host", lldRule.TrapperHosts)
 
-	var terraformConditions []interface{}
-	for _, condition := range lldRule.Filter.Conditions {
-		terraformCondition := map[string]interface{}{}
+	if lldRule.Type == itemTypeHTTPAgent {
+		requestMethod, _ := strconv.Atoi(lldRule.RequestMethod)
+		postType, _ := strconv.Atoi(lldRule.PostType)
+		d.Set("url", lldRule.URL)
+		d.Set("request_method", requestMethod)
+		d.Set("timeout", lldRule.Timeout)
+		d.Set("status_codes", lldRule.StatusCodes)
+		d.Set("follow_redirects", lldRule.FollowRedirects == "1")
+		if lldRule.Headers != nil {
+			d.Set("headers", map[string]string(*lldRule.Headers))
+		}
+		queryFields := []interface{}{}
+		if lldRule.QueryFields != nil {
+			for _, field := range *lldRule.QueryFields {
+				for name, value := range field {
+					queryFields = append(queryFields, map[string]interface{}{
+						"name":  name,
+						"value": value,
+					})
+				}
+			}
+		}
+		d.Set("query_field", queryFields)
+		d.Set("posts", lldRule.Posts)
+		d.Set("post_type", postType)
+		d.Set("http_proxy", lldRule.HTTPProxy)
+	}
 
-		terraformCondition["macro"] = condition.LLDMacro
-		terraformCondition["value"] = condition.Value
-		terraformCondition["operator"] = condition.Operator
-		terraformConditions = append(terraformConditions, terraformCondition)
+	d.Set("filter", createTerraformLLDRuleFilter(lldRule.Filter))
+
+	if lldRule.LLDMacroPaths != nil {
+		var terraformMacroPaths []interface{}
+		for _, macroPath := range *lldRule.LLDMacroPaths {
+			terraformMacroPaths = append(terraformMacroPaths, map[string]interface{}{
+				"lld_macro": macroPath.LLDMacro,
+				"path":      macroPath.Path,
+			})
+		}
+		d.Set("lld_macro_path", terraformMacroPaths)
 	}
 
-	filter := map[string]interface{}{}
-	filter["condition"] = terraformConditions
-	filter["eval_type"] = lldRule.Filter.EvalType
-	filter["formula"] = lldRule.Filter.Formula
+	if lldRule.Preprocessing != nil {
+		d.Set("preprocessing", createTerraformPreprocessing(*lldRule.Preprocessing))
+	}
 
-	d.Set("filter", []interface{}{filter})
+	if lldRule.Overrides != nil {
+		d.Set("override", createTerraformLLDRuleOverrides(*lldRule.Overrides))
+	}
 	return nil
 }
 
-func resourceZabbixLLDRuleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	api := meta.(*zabbix.API)
-
-	_, err := api.DiscoveryRulesGetByID(d.Id())
+func resourceZabbixLLDRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	rule, err := createLLDRuleObject(d, getZabbixServerVersion(meta))
 	if err != nil {
-		if strings.Contains(err.Error(), "Expected exactly one result") {
-			log.Printf("[DEBUG] LLD rule with id %s doesn't exist", d.Id())
-			return false, nil
-		}
-		return false, err
+		return diagFromErr(err)
 	}
-	return true, nil
-}
-
-func resourceZabbixLLDRuleUpdate(d *schema.ResourceData, meta interface{}) error {
-	rule := createLLDRuleObject(d)
 
 	rule.ItemID = d.Id()
-	return createRetry(d, meta, updateLLDRule, rule, resourceZabbixLLDRuleRead)
+	return createRetry(ctx, d, meta, updateLLDRule, *rule, resourceZabbixLLDRuleRead)
 }
 
-func resourceZabbixLLDRuleDelete(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixLLDRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
 
 	err := api.DiscoveryRulesDeletesByIDs([]string{d.Id()})
-	return err
+	return diagFromErr(err)
 }
 
-func createLLDRuleObject(d *schema.ResourceData) zabbix.LLDRule {
-	return zabbix.LLDRule{
-		Delay:       d.Get("delay").(string),
-		HostID:      d.Get("host_id").(string),
-		InterfaceID: d.Get("interface_id").(string),
-		Key:         d.Get("key").(string),
-		Name:        d.Get("name").(string),
-		Type:        zabbix.ItemType(d.Get("type").(int)),
-		Filter:      createLLDRuleConditionObject(d),
+func createLLDRuleObject(d *schema.ResourceData, zabbixVersion string) (*discoveryRule, error) {
+	rule := discoveryRule{
+		LLDRule: zabbix.LLDRule{
+			Delay:        d.Get("delay").(string),
+			HostID:       d.Get("host_id").(string),
+			InterfaceID:  d.Get("interface_id").(string),
+			Key:          d.Get("key").(string),
+			Name:         d.Get("name").(string),
+			Type:         zabbix.ItemType(d.Get("type").(int)),
+			LifeTime:     d.Get("lifetime").(string),
+			Params:       d.Get("params").(string),
+			Username:     d.Get("username").(string),
+			Password:     d.Get("password").(string),
+			TrapperHosts: d.Get("trapper_host").(string),
+			Filter:       createLLDRuleConditionObject(d.Get("filter").(*schema.Set)),
+		},
+		Description: d.Get("description").(string),
+		Status:      d.Get("status").(int),
+	}
+
+	switch rule.Type {
+	case zabbix.SNMPv1Agent, zabbix.SNMPv2Agent, zabbix.SNMPv3Agent, itemTypeSNMPAgent:
+		rule.SnmpOid = d.Get("snmp_oid").(string)
+	case itemTypeDependent:
+		rule.MasterItemID = d.Get("master_item_id").(string)
+	case itemTypeHTTPAgent:
+		rule.URL = d.Get("url").(string)
+		rule.RequestMethod = strconv.Itoa(d.Get("request_method").(int))
+		rule.Timeout = d.Get("timeout").(string)
+		rule.StatusCodes = d.Get("status_codes").(string)
+		rule.Posts = d.Get("posts").(string)
+		rule.PostType = strconv.Itoa(d.Get("post_type").(int))
+		rule.HTTPProxy = d.Get("http_proxy").(string)
+		rule.FollowRedirects = "0"
+		if d.Get("follow_redirects").(bool) {
+			rule.FollowRedirects = "1"
+		}
+		headers := lldRuleHeaders{}
+		for name, value := range d.Get("headers").(map[string]interface{}) {
+			headers[name] = value.(string)
+		}
+		rule.Headers = &headers
+		queryFields := []map[string]string{}
+		for _, terraformQueryField := range d.Get("query_field").([]interface{}) {
+			queryField := terraformQueryField.(map[string]interface{})
+			queryFields = append(queryFields, map[string]string{queryField["name"].(string): queryField["value"].(string)})
+		}
+		rule.QueryFields = &queryFields
+	}
+
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "4.2.0") {
+		macroPaths := []lldMacroPath{}
+		for _, terraformMacroPath := range d.Get("lld_macro_path").(*schema.Set).List() {
+			value := terraformMacroPath.(map[string]interface{})
+			macroPaths = append(macroPaths, lldMacroPath{
+				LLDMacro: value["lld_macro"].(string),
+				Path:     value["path"].(string),
+			})
+		}
+		rule.LLDMacroPaths = &macroPaths
+
+		preprocessing := createPreprocessingObject(d.Get("preprocessing").([]interface{}))
+		rule.Preprocessing = &preprocessing
+	} else if d.Get("lld_macro_path").(*schema.Set).Len() > 0 || len(d.Get("preprocessing").([]interface{})) > 0 {
+		return nil, fmt.Errorf("lld_macro_path and preprocessing require Zabbix 4.2 or higher, server version is %s", zabbixVersion)
 	}
+
+	terraformOverrides := d.Get("override").([]interface{})
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.0.0") {
+		overrides := createLLDRuleOverridesObject(terraformOverrides, configAttribute(d.GetRawConfig(), "override"), zabbixVersion)
+		rule.Overrides = &overrides
+	} else if len(terraformOverrides) > 0 {
+		return nil, attributeErrorf("override", "override requires Zabbix 5.0 or higher, server version is %s", zabbixVersion)
+	}
+
+	return &rule, nil
 }
 
-func createLLDRuleConditionObject(d *schema.ResourceData) zabbix.LLDRuleFilter {
-	filters := d.Get("filter").(*schema.Set)
+func createLLDRuleConditionObject(filters *schema.Set) zabbix.LLDRuleFilter {
+	filterObject := zabbix.LLDRuleFilter{
+		Conditions: zabbix.LLDRulesFilterConditions{},
+	}
+	if filters.Len() == 0 {
+		return filterObject
+	}
 	filter := filters.List()[0].(map[string]interface{})
 	conditions := filter["condition"].(*schema.Set)
-	var filterObject zabbix.LLDRuleFilter
 
 	filterObject.EvalType = filter["eval_type"].(int)
 	filterObject.Formula = filter["formula"].(string)
 	for _, condition := range conditions.List() {
 		value := condition.(map[string]interface{})
 		cond := zabbix.LLDRulesFilterCondition{
-			LLDMacro: value["macro"].(string),
-			Value:    value["value"].(string),
-			Operator: value["operator"].(int),
+			LLDMacro:  value["macro"].(string),
+			Value:     value["value"].(string),
+			Operator:  value["operator"].(int),
+			FormulaID: value["formula_id"].(string),
 		}
 		filterObject.Conditions = append(filterObject.Conditions, cond)
 	}
 	return filterObject
 }
 
-func createLLDRule(rule interface{}, api *zabbix.API) (id string, err error) {
-	rules := zabbix.LLDRules{rule.(zabbix.LLDRule)}
+func createPreprocessingObject(terraformSteps []interface{}) []preprocessingStep {
+	steps := []preprocessingStep{}
+	for _, terraformStep := range terraformSteps {
+		value := terraformStep.(map[string]interface{})
+		var params []string
+		for _, param := range value["params"].([]interface{}) {
+			params = append(params, param.(string))
+		}
+		steps = append(steps, preprocessingStep{
+			Type:               value["type"].(int),
+			Params:             strings.Join(params, "\n"),
+			ErrorHandler:       value["error_handler"].(int),
+			ErrorHandlerParams: value["error_handler_params"].(string),
+		})
+	}
+	return steps
+}
+
+func createLLDRuleOverridesObject(terraformOverrides []interface{}, config cty.Value, zabbixVersion string) []lldRuleOverride {
+	overrides := []lldRuleOverride{}
+	for i, terraformOverride := range terraformOverrides {
+		value := terraformOverride.(map[string]interface{})
+		override := lldRuleOverride{
+			Name:       value["name"].(string),
+			Step:       i + 1,
+			Operations: []lldRuleOverrideOperation{},
+		}
+		if value["stop"].(bool) {
+			override.Stop = 1
+		}
+		if filters := value["filter"].(*schema.Set); filters.Len() > 0 {
+			filter := createLLDRuleConditionObject(filters)
+			override.Filter = &filter
+		}
+
+		operationsConfig := configAttribute(configListElement(config, i), "operation")
+		for j, terraformOperation := range value["operation"].([]interface{}) {
+			operation := createLLDRuleOverrideOperationObject(terraformOperation.(map[string]interface{}), configListElement(operationsConfig, j), zabbixVersion)
+			override.Operations = append(override.Operations, *operation)
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides
+}
+
+// configAttribute returns an attribute of an object of the raw configuration,
+// or cty.NilVal when the configuration isn't available
+func configAttribute(object cty.Value, name string) cty.Value {
+	if object.IsNull() || !object.IsKnown() {
+		return cty.NilVal
+	}
+	return object.GetAttr(name)
+}
+
+// configListElement returns the element i of a list of the raw configuration,
+// or cty.NilVal when the configuration isn't available
+func configListElement(list cty.Value, i int) cty.Value {
+	if list.IsNull() || !list.IsKnown() || list.LengthInt() <= i {
+		return cty.NilVal
+	}
+	return list.Index(cty.NumberIntVal(int64(i)))
+}
+
+// createLLDRuleOverrideOperationObject sends the status, discover, severity and
+// inventory_mode of the configuration, as 0 is one of their values. Without
+// configuration, only their non-zero values are sent.
+func createLLDRuleOverrideOperationObject(value map[string]interface{}, config cty.Value, zabbixVersion string) *lldRuleOverrideOperation {
+	operation := lldRuleOverrideOperation{
+		OperationObject: value["object"].(int),
+		Operator:        value["operator"].(int),
+		Value:           value["value"].(string),
+	}
+
+	configured := func(key string) (int, bool) {
+		v := value[key].(int)
+		if config.IsNull() || !config.IsKnown() {
+			return v, v != 0
+		}
+		return v, !config.GetAttr(key).IsNull()
+	}
+
+	if v, ok := configured("status"); ok {
+		operation.OpStatus = &lldOpStatus{Status: v}
+	}
+	if v, ok := configured("discover"); ok {
+		operation.OpDiscover = &lldOpDiscover{Discover: v}
+	}
+	if v, ok := configured("severity"); ok {
+		operation.OpSeverity = &lldOpSeverity{Severity: v}
+	}
+	if v, ok := configured("inventory_mode"); ok {
+		operation.OpInventory = &lldOpInventory{InventoryMode: v}
+	}
+	if v := value["delay"].(string); v != "" {
+		operation.OpPeriod = &lldOpPeriod{Delay: v}
+	}
+	if v := value["history"].(string); v != "" {
+		operation.OpHistory = &lldOpHistory{History: normalizeDays(v, zabbixVersion)}
+	}
+	if v := value["trends"].(string); v != "" {
+		operation.OpTrends = &lldOpTrends{Trends: normalizeDays(v, zabbixVersion)}
+	}
+	for _, terraformTag := range value["tag"].(*schema.Set).List() {
+		tag := terraformTag.(map[string]interface{})
+		operation.OpTag = append(operation.OpTag, lldOpTag{
+			Tag:   tag["tag"].(string),
+			Value: tag["value"].(string),
+		})
+	}
+	for _, templateID := range value["template_ids"].(*schema.Set).List() {
+		operation.OpTemplate = append(operation.OpTemplate, lldOpTemplate{TemplateID: templateID.(string)})
+	}
+	return &operation
+}
+
+func createTerraformLLDRuleFilter(filter zabbix.LLDRuleFilter) []interface{} {
+	if len(filter.Conditions) == 0 {
+		return []interface{}{}
+	}
+
+	var terraformConditions []interface{}
+	for _, condition := range filter.Conditions {
+		terraformCondition := map[string]interface{}{}
+
+		terraformCondition["macro"] = condition.LLDMacro
+		terraformCondition["value"] = condition.Value
+		terraformCondition["operator"] = condition.Operator
+		// formula IDs are generated by the server unless a custom expression is used
+		terraformCondition["formula_id"] = ""
+		if filter.EvalType == 3 {
+			terraformCondition["formula_id"] = condition.FormulaID
+		}
+		terraformConditions = append(terraformConditions, terraformCondition)
+	}
 
-	err = api.DiscoveryRulesCreate(rules)
+	terraformFilter := map[string]interface{}{}
+	terraformFilter["condition"] = terraformConditions
+	terraformFilter["eval_type"] = filter.EvalType
+	terraformFilter["formula"] = filter.Formula
+
+	return []interface{}{terraformFilter}
+}
+
+func createTerraformPreprocessing(steps []preprocessingStep) []interface{} {
+	var terraformSteps []interface{}
+	for _, step := range steps {
+		var params []string
+		if step.Params != "" {
+			params = strings.Split(step.Params, "\n")
+		}
+		terraformSteps = append(terraformSteps, map[string]interface{}{
+			"type":                 step.Type,
+			"params":               params,
+			"error_handler":        step.ErrorHandler,
+			"error_handler_params": step.ErrorHandlerParams,
+		})
+	}
+	return terraformSteps
+}
+
+func createTerraformLLDRuleOverrides(overrides []lldRuleOverride) []interface{} {
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].Step < overrides[j].Step
+	})
+
+	terraformOverrides := make([]interface{}, len(overrides))
+	for i, override := range overrides {
+		terraformOperations := make([]interface{}, len(override.Operations))
+		for j, operation := range override.Operations {
+			terraformOperation := map[string]interface{}{
+				"object":   operation.OperationObject,
+				"operator": operation.Operator,
+				"value":    operation.Value,
+			}
+			if operation.OpStatus != nil {
+				terraformOperation["status"] = operation.OpStatus.Status
+			}
+			if operation.OpDiscover != nil {
+				terraformOperation["discover"] = operation.OpDiscover.Discover
+			}
+			if operation.OpSeverity != nil {
+				terraformOperation["severity"] = operation.OpSeverity.Severity
+			}
+			if operation.OpInventory != nil {
+				terraformOperation["inventory_mode"] = operation.OpInventory.InventoryMode
+			}
+			if operation.OpPeriod != nil {
+				terraformOperation["delay"] = operation.OpPeriod.Delay
+			}
+			if operation.OpHistory != nil {
+				terraformOperation["history"] = operation.OpHistory.History
+			}
+			if operation.OpTrends != nil {
+				terraformOperation["trends"] = operation.OpTrends.Trends
+			}
+			var tags []interface{}
+			for _, tag := range operation.OpTag {
+				tags = append(tags, map[string]interface{}{
+					"tag":   tag.Tag,
+					"value": tag.Value,
+				})
+			}
+			terraformOperation["tag"] = tags
+			var templateIDs []string
+			for _, template := range operation.OpTemplate {
+				templateIDs = append(templateIDs, template.TemplateID)
+			}
+			terraformOperation["template_ids"] = templateIDs
+			terraformOperations[j] = terraformOperation
+		}
+
+		terraformOverride := map[string]interface{}{
+			"name":      override.Name,
+			"stop":      override.Stop == 1,
+			"filter":    []interface{}{},
+			"operation": terraformOperations,
+		}
+		if override.Filter != nil {
+			terraformOverride["filter"] = createTerraformLLDRuleFilter(*override.Filter)
+		}
+		terraformOverrides[i] = terraformOverride
+	}
+	return terraformOverrides
+}
+
+func createLLDRule(rule interface{}, api *zabbix.API) (id string, err error) {
+	response, err := api.CallWithError("discoveryrule.create", []discoveryRule{rule.(discoveryRule)})
 	if err != nil {
 		return
 	}
-	id = rules[0].ItemID
+
+	result := response.Result.(map[string]interface{})
+	id = result["itemids"].([]interface{})[0].(string)
 	return
 }
 
 func updateLLDRule(rule interface{}, api *zabbix.API) (id string, err error) {
-	rules := zabbix.LLDRules{rule.(zabbix.LLDRule)}
+	lldRule := rule.(discoveryRule)
 
-	err = api.DiscoveryRulesUpdate(rules)
+	_, err = api.CallWithError("discoveryrule.update", []discoveryRule{lldRule})
 	if err != nil {
 		return
 	}
-	id = rules[0].ItemID
+	id = lldRule.ItemID
 	return
 }
@@ -1,21 +1,22 @@
 package zabbix
 
 import (
+	"context"
 	"log"
 
 	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceZabbixLLDRuleLink() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceZabbixLLDRuleLinkCreate,
-		Read:   resourceZabbixLLDRuleLinkRead,
-		Exists: resourceZabbixLLDRuleLinkExists,
-		Update: resourceZabbixLLDRuleLinkUpdate,
-		Delete: resourceZabbixLLDRuleLinkDelete,
+		CreateContext: resourceZabbixLLDRuleLinkCreate,
+		ReadContext:   resourceZabbixLLDRuleLinkRead,
+		UpdateContext: resourceZabbixLLDRuleLinkUpdate,
+		DeleteContext: resourceZabbixLLDRuleLinkDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"lld_rule_id": &schema.Schema{
@@ -66,22 +67,31 @@ func schemaTemplateTriggerPrototype() *schema.Resource {
 	}
 }
 
-func resourceZabbixLLDRuleLinkCreate(d *schema.ResourceData, meta interface{}) error {
-	return resourceZabbixLLDRuleLinkRead(d, meta)
+func resourceZabbixLLDRuleLinkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceZabbixLLDRuleLinkRead(ctx, d, meta)
 }
 
-func resourceZabbixLLDRuleLinkRead(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixLLDRuleLinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	// the link is gone with its LLD rule, the id is empty on create
+	if d.Id() != "" {
+		_, err := api.DiscoveryRulesGetByID(d.Id())
+		if err != nil {
+			return diagFromErr(removeFromStateIfNotFound(d, "LLD rule", err))
+		}
+	}
 
 	itemsTerraform, err := getTerraformTemplateItemPrototypes(d, api)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	d.Set("item_prototype", itemsTerraform)
 
 	triggersTerraform, err := getTerraformTemplateTriggerPrototypes(d, api)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	d.Set("trigger_prototype", triggersTerraform)
 
@@ -89,26 +99,24 @@ func resourceZabbixLLDRuleLinkRead(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
-func resourceZabbixLLDRuleLinkExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	return true, nil
-}
-
-func resourceZabbixLLDRuleLinkUpdate(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixLLDRuleLinkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateItems()
+	api := meta.(*providerClient).api
 
 	err := updateZabbixTemplateItemPrototypes(d, api)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	err = updateZabbixTemplateTriggerPrototypes(d, api)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	return resourceZabbixLLDRuleLinkRead(d, meta)
+	return resourceZabbixLLDRuleLinkRead(ctx, d, meta)
 }
 
-func resourceZabbixLLDRuleLinkDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceZabbixLLDRuleLinkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
 
@@ -2,11 +2,13 @@ package zabbix
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
-	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -59,8 +61,44 @@ func TestAccZabbixLLDRule_Basic(t *testing.T) {
 	})
 }
 
+func TestAccZabbixLLDRule_Full(t *testing.T) {
+	strID := acctest.RandString(5)
+	groupName := fmt.Sprintf("host_group_%s", strID)
+	templateName := fmt.Sprintf("template_%s", strID)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixLLDRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixLLDRuleFullConfig(groupName, templateName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "type", "2"),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "lifetime", "7d"),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "description", "discovery rule without filter"),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "status", "1"),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "filter.#", "0"),
+					resource.TestCheckTypeSetElemNestedAttrs("zabbix_lld_rule.lld_rule_test", "lld_macro_path.*", map[string]string{
+						"lld_macro": "{#FSNAME}",
+						"path":      "$.fsname",
+					}),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "preprocessing.#", "1"),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "preprocessing.0.type", "12"),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "preprocessing.0.params.0", "$.data"),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "override.#", "1"),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "override.0.name", "disable tmpfs"),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "override.0.stop", "true"),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "override.0.operation.0.status", "1"),
+					resource.TestCheckResourceAttr("zabbix_lld_rule.lld_rule_test", "override.0.operation.0.discover", "1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckZabbixLLDRuleDestroy(s *terraform.State) error {
-	api := testAccProvider.Meta().(*zabbix.API)
+	api := testAccProvider.Meta().(*providerClient).api
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "zabbix_lld_rule" {
@@ -82,13 +120,13 @@ func testAccCheckZabbixLLDRuleDestroy(s *terraform.State) error {
 
 func testAccZabbixLLDRuleConfig(groupName, templateName string) string {
 	return fmt.Sprintf(`
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name for template test %s"
 	  	}
 
@@ -113,13 +151,13 @@ func testAccZabbixLLDRuleConfig(groupName, templateName string) string {
 
 func testAccZabbixLLDRuleUpdateConfig(groupName, templateName string) string {
 	return fmt.Sprintf(`
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name for template test %s"
 	  	}
 
@@ -140,3 +178,156 @@ func testAccZabbixLLDRuleUpdateConfig(groupName, templateName string) string {
 		}
 	`, groupName, templateName, templateName)
 }
+
+func testAccZabbixLLDRuleFullConfig(groupName, templateName string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_template_group" "zabbix" {
+			name = "host group test %s"
+		}
+
+		resource "zabbix_template" "template_test" {
+			host = "%s"
+			groups = ["${zabbix_template_group.zabbix.name}"]
+			name = "display name for template test %s"
+		}
+
+		resource "zabbix_lld_rule" "lld_rule_test" {
+			delay = 0
+			host_id = zabbix_template.template_test.id
+			key = "trapper.discovery"
+			name = "test_low_level_discovery_rule_full"
+			type = 2
+			lifetime = "7d"
+			description = "discovery rule without filter"
+			status = 1
+
+			lld_macro_path {
+				lld_macro = "{#FSNAME}"
+				path = "$.fsname"
+			}
+
+			preprocessing {
+				type = 12
+				params = ["$.data"]
+			}
+
+			override {
+				name = "disable tmpfs"
+				stop = true
+				filter {
+					condition {
+						macro = "{#FSNAME}"
+						value = "^tmpfs$"
+					}
+					eval_type = 0
+				}
+				operation {
+					object = 0
+					operator = 2
+					value = "vfs"
+					status = 1
+					discover = 1
+				}
+			}
+		}
+	`, groupName, templateName, templateName)
+}
+
+func TestCreateLLDRuleOverridesObjectStoragePeriods(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceZabbixLLDRule().Schema, map[string]interface{}{
+		"delay":   "1h",
+		"host_id": "10084",
+		"key":     "vfs.fs.discovery",
+		"name":    "Mounted filesystem discovery",
+		"override": []interface{}{
+			map[string]interface{}{
+				"name": "keep less history",
+				"operation": []interface{}{
+					map[string]interface{}{
+						"object":  0,
+						"history": "90",
+						"trends":  "365d",
+					},
+				},
+			},
+		},
+	})
+
+	expected := [2]string{"90d", "365d"}
+	for _, zabbixVersion := range []string{"5.0.0", "6.2.0"} {
+		overrides := createLLDRuleOverridesObject(d.Get("override").([]interface{}), cty.NilVal, zabbixVersion)
+		operation := overrides[0].Operations[0]
+		if operation.OpHistory.History != expected[0] || operation.OpTrends.Trends != expected[1] {
+			t.Errorf("expected history %s and trends %s on Zabbix %s, got %s and %s", expected[0], expected[1], zabbixVersion, operation.OpHistory.History, operation.OpTrends.Trends)
+		}
+	}
+}
+
+func TestCreateLLDRuleOverrideOperationObjectEnums(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceZabbixLLDRule().Schema, map[string]interface{}{
+		"delay":   "1h",
+		"host_id": "10084",
+		"key":     "vfs.fs.discovery",
+		"name":    "Mounted filesystem discovery",
+		"override": []interface{}{
+			map[string]interface{}{
+				"name": "create enabled items",
+				"operation": []interface{}{
+					map[string]interface{}{
+						"object":         0,
+						"status":         0,
+						"inventory_mode": 1,
+					},
+				},
+			},
+		},
+	})
+	value := d.Get("override").([]interface{})[0].(map[string]interface{})["operation"].([]interface{})[0].(map[string]interface{})
+
+	config := cty.ObjectVal(map[string]cty.Value{
+		"status":         cty.NumberIntVal(0),
+		"discover":       cty.NullVal(cty.Number),
+		"severity":       cty.NullVal(cty.Number),
+		"inventory_mode": cty.NumberIntVal(1),
+	})
+	operation := createLLDRuleOverrideOperationObject(value, config, "6.0.0")
+	if operation.OpStatus == nil || operation.OpStatus.Status != 0 {
+		t.Errorf("expected the configured status 0 to be sent, got %v", operation.OpStatus)
+	}
+	if operation.OpDiscover != nil || operation.OpSeverity != nil {
+		t.Errorf("expected discover and severity not to be sent, got %v and %v", operation.OpDiscover, operation.OpSeverity)
+	}
+	if operation.OpInventory == nil || operation.OpInventory.InventoryMode != 1 {
+		t.Errorf("expected the configured inventory mode 1 to be sent, got %v", operation.OpInventory)
+	}
+
+	operation = createLLDRuleOverrideOperationObject(value, cty.NilVal, "6.0.0")
+	if operation.OpStatus != nil || operation.OpInventory == nil {
+		t.Errorf("expected only the non-zero values to be sent without configuration, got %v and %v", operation.OpStatus, operation.OpInventory)
+	}
+}
+
+func TestCreateLLDRuleObjectQueryFields(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceZabbixLLDRule().Schema, map[string]interface{}{
+		"delay":   "1h",
+		"host_id": "10084",
+		"key":     "http.discovery",
+		"name":    "HTTP discovery",
+		"type":    19,
+		"url":     "http://localhost/discovery",
+		"query_field": []interface{}{
+			map[string]interface{}{"name": "sort", "value": "name"},
+			map[string]interface{}{"name": "filter", "value": "web"},
+			map[string]interface{}{"name": "sort", "value": "id"},
+		},
+	})
+
+	rule, err := createLLDRuleObject(d, "6.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]string{{"sort": "name"}, {"filter": "web"}, {"sort": "id"}}
+	if rule.QueryFields == nil || !reflect.DeepEqual(*rule.QueryFields, expected) {
+		t.Fatalf("expected the query fields in order %v, got %v", expected, rule.QueryFields)
+	}
+}
@@ -0,0 +1,366 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// networkDiscoveryRule represent Zabbix network discovery rule object
+// https://www.zabbix.com/documentation/current/manual/api/reference/drule/object
+type networkDiscoveryRule struct {
+	DRuleID     string                  `json:"druleid,omitempty"`
+	Name        string                  `json:"name"`
+	IPRange     string                  `json:"iprange"`
+	Delay       string                  `json:"delay,omitempty"`
+	ProxyHostID string                  `json:"proxy_hostid"`
+	Status      int                     `json:"status,string"`
+	DChecks     []networkDiscoveryCheck `json:"dchecks"`
+}
+
+// networkDiscoveryCheck represent Zabbix discovery check object
+// https://www.zabbix.com/documentation/current/manual/api/reference/dcheck/object
+type networkDiscoveryCheck struct {
+	Type                 int    `json:"type,string"`
+	Key                  string `json:"key_"`
+	Ports                string `json:"ports"`
+	SnmpCommunity        string `json:"snmp_community"`
+	Snmpv3Securityname   string `json:"snmpv3_securityname"`
+	Snmpv3Securitylevel  int    `json:"snmpv3_securitylevel,string"`
+	Snmpv3Authprotocol   int    `json:"snmpv3_authprotocol,string"`
+	Snmpv3Authpassphrase string `json:"snmpv3_authpassphrase"`
+	Snmpv3Privprotocol   int    `json:"snmpv3_privprotocol,string"`
+	Snmpv3Privpassphrase string `json:"snmpv3_privpassphrase"`
+	Snmpv3Contextname    string `json:"snmpv3_contextname"`
+	Uniq                 int    `json:"uniq,string"`
+	HostSource           string `json:"host_source,omitempty"`
+	NameSource           string `json:"name_source,omitempty"`
+}
+
+// NetworkDiscoveryCheckTypes zabbix different discovery check type
+var NetworkDiscoveryCheckTypes = map[string]int{
+	"ssh":          0,
+	"ldap":         1,
+	"smtp":         2,
+	"ftp":          3,
+	"http":         4,
+	"pop":          5,
+	"nntp":         6,
+	"imap":         7,
+	"tcp":          8,
+	"zabbix_agent": 9,
+	"snmpv1":       10,
+	"snmpv2c":      11,
+	"icmp":         12,
+	"snmpv3":       13,
+	"https":        14,
+	"telnet":       15,
+}
+
+func resourceZabbixNetworkDiscoveryRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixNetworkDiscoveryRuleCreate,
+		ReadContext:   resourceZabbixNetworkDiscoveryRuleRead,
+		UpdateContext: resourceZabbixNetworkDiscoveryRuleUpdate,
+		DeleteContext: resourceZabbixNetworkDiscoveryRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the network discovery rule.",
+			},
+			"iprange": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "One or several IP ranges to check separated by commas.",
+			},
+			"delay": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Execution interval of the network discovery rule.",
+			},
+			"proxy_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "0",
+				Description: "ID of the proxy used for discovery.",
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"check": &schema.Schema{
+				Type:     schema.TypeSet,
+				Elem:     schemaNetworkDiscoveryCheck(),
+				Required: true,
+			},
+		},
+	}
+}
+
+func schemaNetworkDiscoveryCheck() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if _, ok := NetworkDiscoveryCheckTypes[v]; !ok {
+						errs = append(errs, fmt.Errorf("%q, %s isn't a valid discovery check type", key, v))
+					}
+					return
+				},
+			},
+			"ports": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "0",
+				Description: "One or several port ranges to check separated by commas.",
+			},
+			"key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Item key for Zabbix agent checks or SNMP OID for SNMP checks.",
+			},
+			"snmp_community": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"snmpv3_securityname": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"snmpv3_securitylevel": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"snmpv3_authprotocol": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"snmpv3_authpassphrase": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Default:   "",
+				Sensitive: true,
+			},
+			"snmpv3_privprotocol": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"snmpv3_privpassphrase": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Default:   "",
+				Sensitive: true,
+			},
+			"snmpv3_contextname": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"uniq": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to use this check as a device uniqueness criteria. Only a single unique check can be configured for a discovery rule.",
+			},
+			"host_source": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "Source for host name (Zabbix 5.0+).",
+			},
+			"name_source": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Source for visible name (Zabbix 5.0+).",
+			},
+		},
+	}
+}
+
+func resourceZabbixNetworkDiscoveryRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	rule, err := createNetworkDiscoveryRuleObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return createRetry(ctx, d, meta, createNetworkDiscoveryRule, *rule, resourceZabbixNetworkDiscoveryRuleRead)
+}
+
+func resourceZabbixNetworkDiscoveryRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	rule, err := getNetworkDiscoveryRuleByID(d.Id(), api)
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "Network discovery rule", err))
+	}
+
+	d.Set("name", rule.Name)
+	d.Set("iprange", rule.IPRange)
+	d.Set("delay", rule.Delay)
+	d.Set("proxy_id", rule.ProxyHostID)
+	d.Set("enabled", rule.Status == 0)
+
+	checkTypes := make(map[int]string, len(NetworkDiscoveryCheckTypes))
+	for name, id := range NetworkDiscoveryCheckTypes {
+		checkTypes[id] = name
+	}
+
+	terraformChecks := make([]interface{}, len(rule.DChecks))
+	for i, check := range rule.DChecks {
+		terraformCheck := map[string]interface{}{
+			"type":                  checkTypes[check.Type],
+			"ports":                 check.Ports,
+			"key":                   check.Key,
+			"snmp_community":        check.SnmpCommunity,
+			"snmpv3_securityname":   check.Snmpv3Securityname,
+			"snmpv3_securitylevel":  check.Snmpv3Securitylevel,
+			"snmpv3_authprotocol":   check.Snmpv3Authprotocol,
+			"snmpv3_authpassphrase": check.Snmpv3Authpassphrase,
+			"snmpv3_privprotocol":   check.Snmpv3Privprotocol,
+			"snmpv3_privpassphrase": check.Snmpv3Privpassphrase,
+			"snmpv3_contextname":    check.Snmpv3Contextname,
+			"uniq":                  check.Uniq == 1,
+			"host_source":           1,
+			"name_source":           0,
+		}
+		if check.HostSource != "" {
+			terraformCheck["host_source"], _ = strconv.Atoi(check.HostSource)
+		}
+		if check.NameSource != "" {
+			terraformCheck["name_source"], _ = strconv.Atoi(check.NameSource)
+		}
+		terraformChecks[i] = terraformCheck
+	}
+	d.Set("check", terraformChecks)
+
+	log.Printf("[DEBUG] Network discovery rule name is %s\n", rule.Name)
+	return nil
+}
+
+func resourceZabbixNetworkDiscoveryRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	rule, err := createNetworkDiscoveryRuleObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	rule.DRuleID = d.Id()
+	return createRetry(ctx, d, meta, updateNetworkDiscoveryRule, *rule, resourceZabbixNetworkDiscoveryRuleRead)
+}
+
+func resourceZabbixNetworkDiscoveryRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	_, err := api.CallWithError("drule.delete", []string{d.Id()})
+	return diagFromErr(err)
+}
+
+func createNetworkDiscoveryRuleObject(d *schema.ResourceData, zabbixVersion string) (*networkDiscoveryRule, error) {
+	rule := networkDiscoveryRule{
+		Name:        d.Get("name").(string),
+		IPRange:     d.Get("iprange").(string),
+		Delay:       d.Get("delay").(string),
+		ProxyHostID: d.Get("proxy_id").(string),
+		Status:      0,
+	}
+
+	if !d.Get("enabled").(bool) {
+		rule.Status = 1
+	}
+
+	uniq := 0
+	for _, terraformCheck := range d.Get("check").(*schema.Set).List() {
+		value := terraformCheck.(map[string]interface{})
+		check := networkDiscoveryCheck{
+			Type:                 NetworkDiscoveryCheckTypes[value["type"].(string)],
+			Key:                  value["key"].(string),
+			Ports:                value["ports"].(string),
+			SnmpCommunity:        value["snmp_community"].(string),
+			Snmpv3Securityname:   value["snmpv3_securityname"].(string),
+			Snmpv3Securitylevel:  value["snmpv3_securitylevel"].(int),
+			Snmpv3Authprotocol:   value["snmpv3_authprotocol"].(int),
+			Snmpv3Authpassphrase: value["snmpv3_authpassphrase"].(string),
+			Snmpv3Privprotocol:   value["snmpv3_privprotocol"].(int),
+			Snmpv3Privpassphrase: value["snmpv3_privpassphrase"].(string),
+			Snmpv3Contextname:    value["snmpv3_contextname"].(string),
+		}
+		if value["uniq"].(bool) {
+			check.Uniq = 1
+			uniq++
+		}
+		if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.0.0") {
+			check.HostSource = strconv.Itoa(value["host_source"].(int))
+			check.NameSource = strconv.Itoa(value["name_source"].(int))
+		}
+		rule.DChecks = append(rule.DChecks, check)
+	}
+
+	if uniq > 1 {
+		return nil, attributeErrorf("check", "Only one check can be used as uniqueness criteria, got %d", uniq)
+	}
+	return &rule, nil
+}
+
+func getNetworkDiscoveryRuleByID(id string, api *zabbix.API) (*networkDiscoveryRule, error) {
+	var rules []networkDiscoveryRule
+
+	err := api.CallWithErrorParse("drule.get", zabbix.Params{
+		"druleids":      id,
+		"output":        "extend",
+		"selectDChecks": "extend",
+	}, &rules)
+	if err != nil {
+		return nil, err
+	}
+	err = expectOneResult("Network discovery rule", id, len(rules))
+	if err != nil {
+		return nil, err
+	}
+	return &rules[0], nil
+}
+
+func createNetworkDiscoveryRule(rule interface{}, api *zabbix.API) (id string, err error) {
+	response, err := api.CallWithError("drule.create", []networkDiscoveryRule{rule.(networkDiscoveryRule)})
+	if err != nil {
+		return
+	}
+
+	result := response.Result.(map[string]interface{})
+	id = result["druleids"].([]interface{})[0].(string)
+	return
+}
+
+func updateNetworkDiscoveryRule(rule interface{}, api *zabbix.API) (id string, err error) {
+	networkRule := rule.(networkDiscoveryRule)
+
+	_, err = api.CallWithError("drule.update", []networkDiscoveryRule{networkRule})
+	if err != nil {
+		return
+	}
+	id = networkRule.DRuleID
+	return
+}
@@ -0,0 +1,112 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccZabbixNetworkDiscoveryRule_Basic(t *testing.T) {
+	resourceName := "zabbix_network_discovery_rule.subnet"
+	ruleName := fmt.Sprintf("network_discovery_%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixNetworkDiscoveryRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixNetworkDiscoveryRuleConfig(ruleName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", ruleName),
+					resource.TestCheckResourceAttr(resourceName, "iprange", "192.168.10.1-254"),
+					resource.TestCheckResourceAttr(resourceName, "delay", "1h"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "check.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "check.*", map[string]string{
+						"type": "icmp",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "check.*", map[string]string{
+						"type":  "zabbix_agent",
+						"ports": "10050",
+						"key":   "system.uname",
+						"uniq":  "true",
+					}),
+				),
+			},
+			{
+				Config: testAccZabbixNetworkDiscoveryRuleUpdateConfig(ruleName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("update_%s", ruleName)),
+					resource.TestCheckResourceAttr(resourceName, "iprange", "192.168.10.0/24,192.168.11.1-10"),
+					resource.TestCheckResourceAttr(resourceName, "delay", "30m"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "check.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "check.*", map[string]string{
+						"type":  "tcp",
+						"ports": "22,80-82",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckZabbixNetworkDiscoveryRuleDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(*providerClient).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "zabbix_network_discovery_rule" {
+			continue
+		}
+
+		_, err := getNetworkDiscoveryRuleByID(rs.Primary.ID, api)
+		if err == nil {
+			return fmt.Errorf("Network discovery rule still exists %s", rs.Primary.ID)
+		}
+		if !isNotFoundError(err) {
+			return fmt.Errorf("expected a not found error, got : %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func testAccZabbixNetworkDiscoveryRuleConfig(ruleName string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_network_discovery_rule" "subnet" {
+			name = "%s"
+			iprange = "192.168.10.1-254"
+			delay = "1h"
+
+			check {
+				type = "icmp"
+			}
+
+			check {
+				type = "zabbix_agent"
+				ports = "10050"
+				key = "system.uname"
+				uniq = true
+			}
+		}
+	`, ruleName)
+}
+
+func testAccZabbixNetworkDiscoveryRuleUpdateConfig(ruleName string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_network_discovery_rule" "subnet" {
+			name = "update_%s"
+			iprange = "192.168.10.0/24,192.168.11.1-10"
+			delay = "30m"
+			enabled = false
+
+			check {
+				type = "tcp"
+				ports = "22,80-82"
+			}
+		}
+	`, ruleName)
+}
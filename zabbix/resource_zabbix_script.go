@@ -0,0 +1,480 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// scriptObject represent Zabbix script object
+// https://www.zabbix.com/documentation/current/manual/api/reference/script/object
+type scriptObject struct {
+	ScriptID     string `json:"scriptid,omitempty"`
+	Name         string `json:"name"`
+	Type         int    `json:"type,string"`
+	Command      string `json:"command"`
+	ExecuteOn    string `json:"execute_on,omitempty"`
+	Description  string `json:"description"`
+	HostAccess   string `json:"host_access,omitempty"`
+	UsrGrpID     string `json:"usrgrpid,omitempty"`
+	GroupID      string `json:"groupid"`
+	Confirmation string `json:"confirmation,omitempty"`
+
+	// Zabbix 5.4+
+	Scope      string             `json:"scope,omitempty"`
+	MenuPath   *string            `json:"menu_path,omitempty"`
+	Port       string             `json:"port,omitempty"`
+	AuthType   string             `json:"authtype,omitempty"`
+	Username   string             `json:"username,omitempty"`
+	Password   string             `json:"password,omitempty"`
+	PublicKey  string             `json:"publickey,omitempty"`
+	PrivateKey string             `json:"privatekey,omitempty"`
+	Timeout    string             `json:"timeout,omitempty"`
+	Parameters *[]scriptParameter `json:"parameters,omitempty"`
+}
+
+type scriptParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ScriptTypes zabbix different script type
+var ScriptTypes = map[string]int{
+	"script":  0,
+	"ipmi":    1,
+	"ssh":     2,
+	"telnet":  3,
+	"webhook": 5,
+}
+
+// ScriptExecuteOn zabbix different script execution location
+var ScriptExecuteOn = map[string]int{
+	"agent":  0,
+	"server": 1,
+	"proxy":  2,
+}
+
+// ScriptScopes zabbix different script scope
+var ScriptScopes = map[string]int{
+	"action_operation": 1,
+	"manual_host":      2,
+	"manual_event":     4,
+}
+
+// ScriptAuthTypes zabbix different SSH script authentication method
+var ScriptAuthTypes = map[string]int{
+	"password":   0,
+	"public_key": 1,
+}
+
+func resourceZabbixScript() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixScriptCreate,
+		ReadContext:   resourceZabbixScriptRead,
+		UpdateContext: resourceZabbixScriptUpdate,
+		DeleteContext: resourceZabbixScriptDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeDiffVersions("zabbix_script", "",
+			versionedAttribute{attribute: "scope", minVersion: "5.4.0"},
+			versionedAttribute{attribute: "menu_path", minVersion: "5.4.0"},
+		),
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the script.",
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "script",
+				Description:  "Type of the script, script, ipmi, ssh (5.4+), telnet (5.4+) or webhook (5.4+).",
+				ValidateFunc: validateTypeName(ScriptTypes),
+			},
+			"command": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Command to run, or the JavaScript code of a webhook.",
+			},
+			"execute_on": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "proxy",
+				Description:  "Where to run the script, agent, server or proxy (server or proxy), used by the script type.",
+				ValidateFunc: validateTypeName(ScriptExecuteOn),
+			},
+			"scope": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Scope of the script, action_operation, manual_host or manual_event (Zabbix 5.4+).",
+				ValidateFunc: validateTypeName(ScriptScopes),
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"host_group_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "0",
+				Description: "ID of the host group the script can run on, 0 for all host groups.",
+			},
+			"user_group_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "0",
+				Description: "ID of the user group allowed to run the script, 0 for all user groups.",
+			},
+			"host_access": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "read",
+				Description: "Host permission required to run the script, read or write.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if v != "read" && v != "write" {
+						errs = append(errs, fmt.Errorf("%q, must be read or write, got %s", key, v))
+					}
+					return
+				},
+			},
+			"confirmation": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Confirmation text displayed before running a manual script.",
+			},
+			"menu_path": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Folders of the script in the context menu, separated by / (Zabbix 5.4+).",
+			},
+			"port": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Port of the SSH or Telnet scripts.",
+			},
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "User name of the SSH or Telnet scripts.",
+			},
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Sensitive:   true,
+				Description: "Password of the SSH or Telnet scripts.",
+			},
+			"auth_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "password",
+				Description:  "Authentication method of the SSH scripts, password or public_key.",
+				ValidateFunc: validateTypeName(ScriptAuthTypes),
+			},
+			"public_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Name of the public key file of the SSH scripts.",
+			},
+			"private_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Name of the private key file of the SSH scripts.",
+			},
+			"timeout": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "30s",
+				Description: "Execution timeout of the webhook scripts, between 1s and 60s.",
+			},
+			"parameter": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Parameters of the webhook scripts.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateTypeName returns a ValidateFunc accepting the names of types
+func validateTypeName(types map[string]int) schema.SchemaValidateFunc {
+	return func(val interface{}, key string) (warns []string, errs []error) {
+		v := val.(string)
+		if _, ok := types[v]; !ok {
+			errs = append(errs, fmt.Errorf("%q, must be one of %v, got %s", key, getSortedTypeNames(types), v))
+		}
+		return
+	}
+}
+
+// getTypeName returns the name of the type id, or an empty string when unknown
+func getTypeName(types map[string]int, id int) string {
+	for name, typeID := range types {
+		if typeID == id {
+			return name
+		}
+	}
+	return ""
+}
+
+func getSortedTypeNames(types map[string]int) []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func resourceZabbixScriptCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	script, err := createScriptObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return createRetry(ctx, d, meta, createScript, *script, resourceZabbixScriptRead)
+}
+
+func resourceZabbixScriptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	script, err := getScriptByID(d.Id(), api)
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "Script", err))
+	}
+
+	d.Set("name", script.Name)
+	d.Set("type", getTypeName(ScriptTypes, script.Type))
+	d.Set("command", script.Command)
+	d.Set("description", script.Description)
+	d.Set("host_group_id", script.GroupID)
+	d.Set("confirmation", script.Confirmation)
+
+	if script.ExecuteOn != "" {
+		executeOn, _ := strconv.Atoi(script.ExecuteOn)
+		d.Set("execute_on", getTypeName(ScriptExecuteOn, executeOn))
+	}
+	if script.UsrGrpID != "" {
+		d.Set("user_group_id", script.UsrGrpID)
+	}
+	if script.HostAccess == "3" {
+		d.Set("host_access", "write")
+	} else {
+		d.Set("host_access", "read")
+	}
+
+	if script.Scope != "" {
+		scope, _ := strconv.Atoi(script.Scope)
+		d.Set("scope", getTypeName(ScriptScopes, scope))
+	}
+	if script.MenuPath != nil {
+		d.Set("menu_path", *script.MenuPath)
+	}
+	if script.Type == ScriptTypes["ssh"] || script.Type == ScriptTypes["telnet"] {
+		d.Set("port", script.Port)
+		d.Set("username", script.Username)
+		d.Set("password", script.Password)
+	}
+	if script.Type == ScriptTypes["ssh"] {
+		authType, _ := strconv.Atoi(script.AuthType)
+		d.Set("auth_type", getTypeName(ScriptAuthTypes, authType))
+		d.Set("public_key", script.PublicKey)
+		d.Set("private_key", script.PrivateKey)
+	}
+	if script.Type == ScriptTypes["webhook"] {
+		d.Set("timeout", script.Timeout)
+		if script.Parameters != nil {
+			parameters := make([]interface{}, len(*script.Parameters))
+			for i, parameter := range *script.Parameters {
+				parameters[i] = map[string]interface{}{
+					"name":  parameter.Name,
+					"value": parameter.Value,
+				}
+			}
+			d.Set("parameter", parameters)
+		}
+	}
+
+	log.Printf("[DEBUG] Script name is %s\n", script.Name)
+	return nil
+}
+
+func resourceZabbixScriptUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	script, err := createScriptObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	script.ScriptID = d.Id()
+	return createRetry(ctx, d, meta, updateScript, *script, resourceZabbixScriptRead)
+}
+
+func resourceZabbixScriptDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	_, err := api.CallWithError("script.delete", []string{d.Id()})
+	return diagFromErr(err)
+}
+
+func createScriptObject(d *schema.ResourceData, zabbixVersion string) (*scriptObject, error) {
+	scriptType := d.Get("type").(string)
+	script := scriptObject{
+		Name:        d.Get("name").(string),
+		Type:        ScriptTypes[scriptType],
+		Command:     d.Get("command").(string),
+		Description: d.Get("description").(string),
+		GroupID:     d.Get("host_group_id").(string),
+	}
+
+	if scriptType == "script" {
+		script.ExecuteOn = strconv.Itoa(ScriptExecuteOn[d.Get("execute_on").(string)])
+	}
+
+	if !isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.4.0") {
+		if scriptType != "script" && scriptType != "ipmi" {
+			return nil, attributeErrorf("type", "Script type %s requires Zabbix 5.4 or higher, server version is %s", scriptType, zabbixVersion)
+		}
+		if d.Get("scope").(string) != "" || d.Get("menu_path").(string) != "" {
+			return nil, fmt.Errorf("Script scope and menu_path require Zabbix 5.4 or higher, server version is %s", zabbixVersion)
+		}
+		// every script is a manual host script before Zabbix 5.4
+		setScriptManualProperties(d, &script)
+		return &script, nil
+	}
+
+	scope := d.Get("scope").(string)
+	if scope == "" {
+		scope = "action_operation"
+	}
+	script.Scope = strconv.Itoa(ScriptScopes[scope])
+	if scope == "action_operation" {
+		if d.Get("confirmation").(string) != "" || d.Get("menu_path").(string) != "" ||
+			d.Get("user_group_id").(string) != "0" || d.Get("host_access").(string) != "read" {
+			return nil, fmt.Errorf("Script confirmation, menu_path, user_group_id and host_access are only used by the manual scopes")
+		}
+	} else {
+		setScriptManualProperties(d, &script)
+		menuPath := d.Get("menu_path").(string)
+		script.MenuPath = &menuPath
+	}
+
+	switch scriptType {
+	case "ssh", "telnet":
+		script.Port = d.Get("port").(string)
+		script.Username = d.Get("username").(string)
+		script.Password = d.Get("password").(string)
+		if script.Username == "" {
+			return nil, attributeErrorf("username", "Script type %s requires a username", scriptType)
+		}
+		if scriptType == "ssh" {
+			authType := d.Get("auth_type").(string)
+			script.AuthType = strconv.Itoa(ScriptAuthTypes[authType])
+			if authType == "public_key" {
+				script.PublicKey = d.Get("public_key").(string)
+				script.PrivateKey = d.Get("private_key").(string)
+				if script.PublicKey == "" || script.PrivateKey == "" {
+					return nil, attributeErrorf("private_key", "Script auth_type public_key requires public_key and private_key")
+				}
+			}
+		}
+	case "webhook":
+		script.Timeout = d.Get("timeout").(string)
+		parameters := []scriptParameter{}
+		for _, p := range d.Get("parameter").(*schema.Set).List() {
+			parameter := p.(map[string]interface{})
+			parameters = append(parameters, scriptParameter{
+				Name:  parameter["name"].(string),
+				Value: parameter["value"].(string),
+			})
+		}
+		script.Parameters = &parameters
+	}
+
+	if scriptType != "webhook" && d.Get("parameter").(*schema.Set).Len() > 0 {
+		return nil, attributeErrorf("parameter", "Script parameters are only used by the webhook type, got %s", scriptType)
+	}
+	return &script, nil
+}
+
+// setScriptManualProperties sets the properties of the scripts run from the frontend
+func setScriptManualProperties(d *schema.ResourceData, script *scriptObject) {
+	script.UsrGrpID = d.Get("user_group_id").(string)
+	script.Confirmation = d.Get("confirmation").(string)
+	script.HostAccess = "2"
+	if d.Get("host_access").(string) == "write" {
+		script.HostAccess = "3"
+	}
+}
+
+func getScriptByID(id string, api *zabbix.API) (*scriptObject, error) {
+	var scripts []scriptObject
+
+	err := api.CallWithErrorParse("script.get", zabbix.Params{
+		"scriptids": id,
+		"output":    "extend",
+	}, &scripts)
+	if err != nil {
+		return nil, err
+	}
+	err = expectOneResult("Script", id, len(scripts))
+	if err != nil {
+		return nil, err
+	}
+	return &scripts[0], nil
+}
+
+func createScript(script interface{}, api *zabbix.API) (id string, err error) {
+	response, err := api.CallWithError("script.create", []scriptObject{script.(scriptObject)})
+	if err != nil {
+		return
+	}
+
+	result := response.Result.(map[string]interface{})
+	id = result["scriptids"].([]interface{})[0].(string)
+	return
+}
+
+func updateScript(script interface{}, api *zabbix.API) (id string, err error) {
+	zabbixScript := script.(scriptObject)
+
+	_, err = api.CallWithError("script.update", []scriptObject{zabbixScript})
+	if err != nil {
+		return
+	}
+	id = zabbixScript.ScriptID
+	return
+}
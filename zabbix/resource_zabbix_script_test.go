@@ -0,0 +1,136 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccZabbixScript_Basic(t *testing.T) {
+	resourceName := "zabbix_script.script_test"
+	strID := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixScriptDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixScriptConfig(strID, "uptime"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("script_%s", strID)),
+					resource.TestCheckResourceAttr(resourceName, "type", "script"),
+					resource.TestCheckResourceAttr(resourceName, "command", "uptime"),
+					resource.TestCheckResourceAttr(resourceName, "execute_on", "agent"),
+					resource.TestCheckResourceAttr(resourceName, "host_access", "write"),
+					resource.TestCheckResourceAttr(resourceName, "confirmation", "Run uptime?"),
+				),
+			},
+			{
+				Config: testAccZabbixScriptConfig(strID, "uptime -p"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "command", "uptime -p"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccZabbixScript_Webhook(t *testing.T) {
+	resourceName := "zabbix_script.webhook_test"
+	strID := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfZabbixVersionLower(t, "5.4.0")
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixScriptDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixScriptWebhook(strID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "webhook"),
+					resource.TestCheckResourceAttr(resourceName, "scope", "manual_event"),
+					resource.TestCheckResourceAttr(resourceName, "menu_path", "Tickets"),
+					resource.TestCheckResourceAttr(resourceName, "timeout", "10s"),
+					resource.TestCheckResourceAttr(resourceName, "parameter.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{"name": "event", "value": "{EVENT.ID}"}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckZabbixScriptDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(*providerClient).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "zabbix_script" {
+			continue
+		}
+
+		_, err := getScriptByID(rs.Primary.ID, api)
+		if err == nil {
+			return fmt.Errorf("Script still exists %s", rs.Primary.ID)
+		}
+		if !isNotFoundError(err) {
+			return fmt.Errorf("expected a not found error, got : %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func testAccZabbixScriptConfig(strID, command string) string {
+	return fmt.Sprintf(`
+		data "zabbix_server" "test" {
+			compare_version = "5.4"
+		}
+
+		resource "zabbix_script" "script_test" {
+			name = "script_%s"
+			command = "%s"
+			execute_on = "agent"
+			scope = data.zabbix_server.test.server_version_ge ? "manual_host" : null
+			host_access = "write"
+			confirmation = "Run uptime?"
+		}
+	`, strID, command)
+}
+
+func testAccZabbixScriptWebhook(strID string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_script" "webhook_test" {
+			name = "webhook_%s"
+			type = "webhook"
+			scope = "manual_event"
+			menu_path = "Tickets"
+			command = "var params = JSON.parse(value); return params.event;"
+			timeout = "10s"
+
+			parameter {
+				name = "event"
+				value = "{EVENT.ID}"
+			}
+
+			parameter {
+				name = "host"
+				value = "{HOST.NAME}"
+			}
+		}
+	`, strID)
+}
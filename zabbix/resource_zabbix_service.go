@@ -0,0 +1,565 @@
+package zabbix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serviceObject represent Zabbix service object, the fields depend on the
+// server version as the services were redesigned in Zabbix 6.0
+// https://www.zabbix.com/documentation/current/manual/api/reference/service/object
+// https://www.zabbix.com/documentation/5.0/manual/api/reference/service/object
+type serviceObject struct {
+	ServiceID string `json:"serviceid,omitempty"`
+	Name      string `json:"name"`
+	Algorithm int    `json:"algorithm,string"`
+	SortOrder int    `json:"sortorder,string"`
+
+	// Zabbix 6.0+
+	Description      *string              `json:"description,omitempty"`
+	Weight           string               `json:"weight,omitempty"`
+	PropagationRule  string               `json:"propagation_rule,omitempty"`
+	PropagationValue string               `json:"propagation_value,omitempty"`
+	Parents          *[]serviceRef        `json:"parents,omitempty"`
+	Children         []serviceRef         `json:"children,omitempty"`
+	Tags             *[]tagData           `json:"tags,omitempty"`
+	ProblemTags      *[]problemTag        `json:"problem_tags,omitempty"`
+	StatusRules      *[]serviceStatusRule `json:"status_rules,omitempty"`
+
+	// before Zabbix 6.0
+	ShowSLA      string              `json:"showsla,omitempty"`
+	GoodSLA      string              `json:"goodsla,omitempty"`
+	TriggerID    string              `json:"triggerid,omitempty"`
+	ParentID     string              `json:"parentid,omitempty"`
+	Parent       json.RawMessage     `json:"parent,omitempty"`
+	Dependencies []serviceDependency `json:"dependencies,omitempty"`
+}
+
+type serviceRef struct {
+	ServiceID string `json:"serviceid"`
+}
+
+// problemTag represent the tags matched by a service or a SLA
+type problemTag struct {
+	Tag      string `json:"tag"`
+	Operator int    `json:"operator,string"`
+	Value    string `json:"value"`
+}
+
+type serviceStatusRule struct {
+	Type        int `json:"type,string"`
+	LimitValue  int `json:"limit_value,string"`
+	LimitStatus int `json:"limit_status,string"`
+	NewStatus   int `json:"new_status,string"`
+}
+
+// serviceDependency represent a child service before Zabbix 6.0
+type serviceDependency struct {
+	ServiceDownID string `json:"servicedownid"`
+	Soft          string `json:"soft"`
+}
+
+func resourceZabbixService() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixServiceCreate,
+		ReadContext:   resourceZabbixServiceRead,
+		UpdateContext: resourceZabbixServiceUpdate,
+		DeleteContext: resourceZabbixServiceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeDiffVersions("zabbix_service", "",
+			versionedAttribute{attribute: "description", minVersion: "6.0.0"},
+			versionedAttribute{attribute: "weight", minVersion: "6.0.0"},
+			versionedAttribute{attribute: "propagation_rule", minVersion: "6.0.0"},
+			versionedAttribute{attribute: "propagation_value", minVersion: "6.0.0"},
+			versionedAttribute{attribute: "tag", minVersion: "6.0.0"},
+			versionedAttribute{attribute: "problem_tag", minVersion: "6.0.0"},
+			versionedAttribute{attribute: "status_rule", minVersion: "6.0.0"},
+			versionedAttribute{attribute: "trigger_id", removedVersion: "6.0.0"},
+			versionedAttribute{attribute: "show_sla", removedVersion: "6.0.0"},
+		),
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the service.",
+			},
+			"algorithm": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Status calculation rule, 0 always sets the status to OK (do not calculate before Zabbix 6.0).",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 2 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 2 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"sort_order": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Position of the service used for sorting.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 999 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 999 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"parent_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "IDs of the parent services, at most one before Zabbix 6.0.",
+			},
+			"child_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "IDs of the child services.",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Description of the service (Zabbix 6.0+).",
+			},
+			"weight": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Weight of the service used by the parent status rules (Zabbix 6.0+).",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 1000000 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 1000000 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"propagation_rule": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Status propagation rule to the parent services (Zabbix 6.0+).",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 4 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 4 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"propagation_value": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Value of the propagation rule, a severity or -1 for OK (Zabbix 6.0+).",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < -1 || v > 5 {
+						errs = append(errs, fmt.Errorf("%q, must be between -1 and 5 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"tag": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tags of the service (Zabbix 6.0+).",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tag": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+						},
+					},
+				},
+			},
+			"problem_tag": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tags of the problems affecting the service status (Zabbix 6.0+).",
+				Elem:        schemaProblemTag(),
+			},
+			"status_rule": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Additional rules of the status calculation (Zabbix 6.0+).",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(int)
+								if v < 0 || v > 7 {
+									errs = append(errs, fmt.Errorf("%q, must be between 0 and 7 inclusive, got %d", key, v))
+								}
+								return
+							},
+						},
+						"limit_value": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"limit_status": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(int)
+								if v < -1 || v > 5 {
+									errs = append(errs, fmt.Errorf("%q, must be between -1 and 5 inclusive, got %d", key, v))
+								}
+								return
+							},
+						},
+						"new_status": &schema.Schema{
+							Type:     schema.TypeInt,
+							Required: true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(int)
+								if v < 0 || v > 5 {
+									errs = append(errs, fmt.Errorf("%q, must be between 0 and 5 inclusive, got %d", key, v))
+								}
+								return
+							},
+						},
+					},
+				},
+			},
+			"trigger_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "ID of the trigger linked to the service (before Zabbix 6.0).",
+			},
+			"show_sla": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Calculate the SLA of the service (before Zabbix 6.0).",
+			},
+			"good_sla": &schema.Schema{
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Default:     99.9,
+				Description: "Minimum acceptable SLA value in percent (before Zabbix 6.0).",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(float64)
+					if v < 0 || v > 100 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 100 inclusive, got %g", key, v))
+					}
+					return
+				},
+			},
+		},
+	}
+}
+
+func schemaProblemTag() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tag": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"operator": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Condition operator, 0 equals and 2 contains.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v != 0 && v != 2 {
+						errs = append(errs, fmt.Errorf("%q, must be 0 or 2, got %d", key, v))
+					}
+					return
+				},
+			},
+			"value": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+		},
+	}
+}
+
+func resourceZabbixServiceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	service, err := createServiceObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return createRetry(ctx, d, meta, createService, *service, resourceZabbixServiceRead)
+}
+
+func resourceZabbixServiceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+	zabbixVersion := getZabbixServerVersion(meta)
+
+	service, err := getServiceByID(d.Id(), api, zabbixVersion)
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "Service", err))
+	}
+
+	d.Set("name", service.Name)
+	d.Set("algorithm", service.Algorithm)
+	d.Set("sort_order", service.SortOrder)
+
+	var parentIDs, childIDs []string
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.0.0") {
+		if service.Parents != nil {
+			for _, parent := range *service.Parents {
+				parentIDs = append(parentIDs, parent.ServiceID)
+			}
+		}
+		for _, child := range service.Children {
+			childIDs = append(childIDs, child.ServiceID)
+		}
+
+		if service.Description != nil {
+			d.Set("description", *service.Description)
+		}
+		weight, _ := strconv.Atoi(service.Weight)
+		d.Set("weight", weight)
+		propagationRule, _ := strconv.Atoi(service.PropagationRule)
+		d.Set("propagation_rule", propagationRule)
+		propagationValue, _ := strconv.Atoi(service.PropagationValue)
+		d.Set("propagation_value", propagationValue)
+		if service.Tags != nil {
+			d.Set("tag", flattenTagsData(*service.Tags))
+		}
+		if service.ProblemTags != nil {
+			d.Set("problem_tag", flattenProblemTags(*service.ProblemTags))
+		}
+		if service.StatusRules != nil {
+			d.Set("status_rule", flattenServiceStatusRules(*service.StatusRules))
+		}
+	} else {
+		// the parent is an object, or an empty array when the service has none
+		var parent serviceRef
+		if err := json.Unmarshal(service.Parent, &parent); err == nil && parent.ServiceID != "" {
+			parentIDs = append(parentIDs, parent.ServiceID)
+		}
+		for _, dependency := range service.Dependencies {
+			if dependency.Soft == "0" {
+				childIDs = append(childIDs, dependency.ServiceDownID)
+			}
+		}
+
+		triggerID := service.TriggerID
+		if triggerID == "0" {
+			triggerID = ""
+		}
+		d.Set("trigger_id", triggerID)
+		d.Set("show_sla", service.ShowSLA == "1")
+		goodSLA, _ := strconv.ParseFloat(service.GoodSLA, 64)
+		d.Set("good_sla", goodSLA)
+	}
+	d.Set("parent_ids", parentIDs)
+	d.Set("child_ids", childIDs)
+
+	log.Printf("[DEBUG] Service name is %s\n", service.Name)
+	return nil
+}
+
+func resourceZabbixServiceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	service, err := createServiceObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	service.ServiceID = d.Id()
+	return createRetry(ctx, d, meta, updateService, *service, resourceZabbixServiceRead)
+}
+
+func resourceZabbixServiceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	_, err := api.CallWithError("service.delete", []string{d.Id()})
+	return diagFromErr(err)
+}
+
+func createServiceObject(d *schema.ResourceData, zabbixVersion string) (*serviceObject, error) {
+	service := serviceObject{
+		Name:      d.Get("name").(string),
+		Algorithm: d.Get("algorithm").(int),
+		SortOrder: d.Get("sort_order").(int),
+	}
+	parentIDs := d.Get("parent_ids").(*schema.Set).List()
+
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.0.0") {
+		if d.Get("trigger_id").(string) != "" || d.Get("show_sla").(bool) {
+			return nil, fmt.Errorf("Service trigger_id and show_sla are replaced by problem tags and SLAs from Zabbix 6.0, server version is %s", zabbixVersion)
+		}
+
+		description := d.Get("description").(string)
+		service.Description = &description
+		service.Weight = strconv.Itoa(d.Get("weight").(int))
+		service.PropagationRule = strconv.Itoa(d.Get("propagation_rule").(int))
+		service.PropagationValue = strconv.Itoa(d.Get("propagation_value").(int))
+
+		parents := make([]serviceRef, len(parentIDs))
+		for i, parentID := range parentIDs {
+			parents[i] = serviceRef{ServiceID: parentID.(string)}
+		}
+		service.Parents = &parents
+
+		tags := createTagsData(d)
+		service.Tags = &tags
+		problemTags := createProblemTags(d.Get("problem_tag").(*schema.Set))
+		service.ProblemTags = &problemTags
+
+		statusRules := []serviceStatusRule{}
+		for _, r := range d.Get("status_rule").(*schema.Set).List() {
+			rule := r.(map[string]interface{})
+			statusRules = append(statusRules, serviceStatusRule{
+				Type:        rule["type"].(int),
+				LimitValue:  rule["limit_value"].(int),
+				LimitStatus: rule["limit_status"].(int),
+				NewStatus:   rule["new_status"].(int),
+			})
+		}
+		service.StatusRules = &statusRules
+		return &service, nil
+	}
+
+	if d.Get("description").(string) != "" || d.Get("weight").(int) != 0 || d.Get("propagation_rule").(int) != 0 ||
+		d.Get("propagation_value").(int) != 0 || d.Get("tag").(*schema.Set).Len() > 0 ||
+		d.Get("problem_tag").(*schema.Set).Len() > 0 || d.Get("status_rule").(*schema.Set).Len() > 0 {
+		return nil, fmt.Errorf("Service description, weight, propagation, tags and status rules require Zabbix 6.0 or higher, server version is %s", zabbixVersion)
+	}
+	if len(parentIDs) > 1 {
+		return nil, attributeErrorf("parent_ids", "Services have at most one parent before Zabbix 6.0, got %d", len(parentIDs))
+	}
+
+	// 0 removes the parent or the trigger of an existing service
+	if len(parentIDs) == 1 {
+		service.ParentID = parentIDs[0].(string)
+	} else if d.Id() != "" {
+		service.ParentID = "0"
+	}
+	if triggerID := d.Get("trigger_id").(string); triggerID != "" {
+		service.TriggerID = triggerID
+	} else if d.Id() != "" {
+		service.TriggerID = "0"
+	}
+	service.ShowSLA = "0"
+	if d.Get("show_sla").(bool) {
+		service.ShowSLA = "1"
+	}
+	service.GoodSLA = strconv.FormatFloat(d.Get("good_sla").(float64), 'f', -1, 64)
+	return &service, nil
+}
+
+func createProblemTags(terraformTags *schema.Set) []problemTag {
+	tags := []problemTag{}
+	for _, t := range terraformTags.List() {
+		tag := t.(map[string]interface{})
+		tags = append(tags, problemTag{
+			Tag:      tag["tag"].(string),
+			Operator: tag["operator"].(int),
+			Value:    tag["value"].(string),
+		})
+	}
+	return tags
+}
+
+func flattenProblemTags(tags []problemTag) []interface{} {
+	terraformTags := make([]interface{}, len(tags))
+	for i, tag := range tags {
+		terraformTags[i] = map[string]interface{}{
+			"tag":      tag.Tag,
+			"operator": tag.Operator,
+			"value":    tag.Value,
+		}
+	}
+	return terraformTags
+}
+
+func flattenServiceStatusRules(rules []serviceStatusRule) []interface{} {
+	terraformRules := make([]interface{}, len(rules))
+	for i, rule := range rules {
+		terraformRules[i] = map[string]interface{}{
+			"type":         rule.Type,
+			"limit_value":  rule.LimitValue,
+			"limit_status": rule.LimitStatus,
+			"new_status":   rule.NewStatus,
+		}
+	}
+	return terraformRules
+}
+
+func getServiceByID(id string, api *zabbix.API, zabbixVersion string) (*serviceObject, error) {
+	var services []serviceObject
+
+	params := zabbix.Params{
+		"serviceids": id,
+		"output":     "extend",
+	}
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.0.0") {
+		params["selectParents"] = []string{"serviceid"}
+		params["selectChildren"] = []string{"serviceid"}
+		params["selectTags"] = "extend"
+		params["selectProblemTags"] = "extend"
+		params["selectStatusRules"] = "extend"
+	} else {
+		params["selectParent"] = []string{"serviceid"}
+		params["selectDependencies"] = "extend"
+	}
+
+	err := api.CallWithErrorParse("service.get", params, &services)
+	if err != nil {
+		return nil, err
+	}
+	err = expectOneResult("Service", id, len(services))
+	if err != nil {
+		return nil, err
+	}
+	return &services[0], nil
+}
+
+func createService(service interface{}, api *zabbix.API) (id string, err error) {
+	response, err := api.CallWithError("service.create", []serviceObject{service.(serviceObject)})
+	if err != nil {
+		return
+	}
+
+	result := response.Result.(map[string]interface{})
+	id = result["serviceids"].([]interface{})[0].(string)
+	return
+}
+
+func updateService(service interface{}, api *zabbix.API) (id string, err error) {
+	zabbixService := service.(serviceObject)
+
+	_, err = api.CallWithError("service.update", []serviceObject{zabbixService})
+	if err != nil {
+		return
+	}
+	id = zabbixService.ServiceID
+	return
+}
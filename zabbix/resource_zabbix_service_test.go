@@ -0,0 +1,142 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccZabbixService_Basic(t *testing.T) {
+	strID := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixServiceConfig(strID, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_service.parent", "name", fmt.Sprintf("parent_%s", strID)),
+					resource.TestCheckResourceAttr("zabbix_service.child", "algorithm", "1"),
+					resource.TestCheckResourceAttr("zabbix_service.child", "sort_order", "10"),
+					resource.TestCheckTypeSetElemAttrPair("zabbix_service.child", "parent_ids.*", "zabbix_service.parent", "id"),
+				),
+			},
+			{
+				Config: testAccZabbixServiceConfig(strID, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_service.child", "algorithm", "2"),
+					resource.TestCheckResourceAttr("zabbix_service.parent", "child_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("zabbix_service.parent", "child_ids.*", "zabbix_service.child", "id"),
+				),
+			},
+			{
+				ResourceName:      "zabbix_service.child",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccZabbixService_StatusRules(t *testing.T) {
+	strID := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfZabbixVersionLower(t, "6.0.0")
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixServiceStatusRulesConfig(strID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_service.service_test", "description", "database cluster"),
+					resource.TestCheckResourceAttr("zabbix_service.service_test", "weight", "5"),
+					resource.TestCheckResourceAttr("zabbix_service.service_test", "propagation_rule", "1"),
+					resource.TestCheckResourceAttr("zabbix_service.service_test", "propagation_value", "4"),
+					resource.TestCheckTypeSetElemNestedAttrs("zabbix_service.service_test", "tag.*", map[string]string{"tag": "team", "value": "sre"}),
+					resource.TestCheckTypeSetElemNestedAttrs("zabbix_service.service_test", "problem_tag.*", map[string]string{"tag": "service", "operator": "2", "value": "db"}),
+					resource.TestCheckTypeSetElemNestedAttrs("zabbix_service.service_test", "status_rule.*", map[string]string{"type": "1", "limit_value": "50", "limit_status": "2", "new_status": "4"}),
+				),
+			},
+			{
+				ResourceName:      "zabbix_service.service_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckZabbixServiceDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(*providerClient).api
+	zabbixVersion := getZabbixServerVersion(testAccProvider.Meta())
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "zabbix_service" {
+			continue
+		}
+
+		_, err := getServiceByID(rs.Primary.ID, api, zabbixVersion)
+		if err == nil {
+			return fmt.Errorf("Service still exists %s", rs.Primary.ID)
+		}
+		if !isNotFoundError(err) {
+			return fmt.Errorf("expected a not found error, got : %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func testAccZabbixServiceConfig(strID string, algorithm int) string {
+	return fmt.Sprintf(`
+		resource "zabbix_service" "parent" {
+			name = "parent_%s"
+		}
+
+		resource "zabbix_service" "child" {
+			name = "child_%s"
+			algorithm = %d
+			sort_order = 10
+			parent_ids = [zabbix_service.parent.id]
+		}
+	`, strID, strID, algorithm)
+}
+
+func testAccZabbixServiceStatusRulesConfig(strID string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_service" "service_test" {
+			name = "service_%s"
+			algorithm = 2
+			description = "database cluster"
+			weight = 5
+			propagation_rule = 1
+			propagation_value = 4
+
+			tag {
+				tag = "team"
+				value = "sre"
+			}
+
+			problem_tag {
+				tag = "service"
+				operator = 2
+				value = "db"
+			}
+
+			status_rule {
+				type = 1
+				limit_value = 50
+				limit_status = 2
+				new_status = 4
+			}
+		}
+	`, strID)
+}
@@ -0,0 +1,273 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// settingsID is the ID of the singleton zabbix_settings resource
+const settingsID = "settings"
+
+// settingsFields maps the zabbix_settings attributes to the settings object properties
+// https://www.zabbix.com/documentation/current/manual/api/reference/settings/object
+var settingsFields = map[string]string{
+	"default_theme":         "default_theme",
+	"server_check_interval": "server_check_interval",
+	"frontend_url":          "url",
+}
+
+// housekeepingFields maps the zabbix_settings attributes to the housekeeping object properties
+// https://www.zabbix.com/documentation/current/manual/api/reference/housekeeping/object
+var housekeepingFields = map[string]string{
+	"history_housekeeping": "hk_history_mode",
+	"history_override":     "hk_history_global",
+	"history_period":       "hk_history",
+	"trends_housekeeping":  "hk_trends_mode",
+	"trends_override":      "hk_trends_global",
+	"trends_period":        "hk_trends",
+}
+
+// severityLevels are the keys of the severity_names and severity_colors attributes
+var severityLevels = []string{"0", "1", "2", "3", "4", "5"}
+
+func resourceZabbixSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixSettingsCreate,
+		ReadContext:   resourceZabbixSettingsRead,
+		UpdateContext: resourceZabbixSettingsUpdate,
+		DeleteContext: resourceZabbixSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeDiffVersions("zabbix_settings", "5.2.0"),
+		Schema: map[string]*schema.Schema{
+			"default_theme": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Default frontend theme.",
+			},
+			"server_check_interval": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Interval in seconds of the Zabbix server status check, 0 disables it.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v != 0 && v != 10 {
+						errs = append(errs, fmt.Errorf("%q, must be 0 or 10, got %d", key, v))
+					}
+					return
+				},
+			},
+			"frontend_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "URL of the Zabbix frontend.",
+			},
+			"severity_names": &schema.Schema{
+				Type:         schema.TypeMap,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Optional:     true,
+				Description:  "Names of the trigger severities, keyed by severity level from 0 to 5.",
+				ValidateFunc: validateSeverityMap,
+			},
+			"severity_colors": &schema.Schema{
+				Type:         schema.TypeMap,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Optional:     true,
+				Description:  "Colors of the trigger severities as hexadecimal RGB, keyed by severity level from 0 to 5.",
+				ValidateFunc: validateSeverityMap,
+			},
+			"history_housekeeping": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Enable internal housekeeping for history.",
+			},
+			"history_override": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Override item history period.",
+			},
+			"history_period": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "History data storage period, a time unit with suffix.",
+			},
+			"trends_housekeeping": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Enable internal housekeeping for trends.",
+			},
+			"trends_override": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Override item trends period.",
+			},
+			"trends_period": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Trends data storage period, a time unit with suffix.",
+			},
+		},
+	}
+}
+
+func validateSeverityMap(val interface{}, key string) (warns []string, errs []error) {
+	for level := range val.(map[string]interface{}) {
+		if v, err := strconv.Atoi(level); err != nil || v < 0 || v > 5 {
+			errs = append(errs, fmt.Errorf("%q, keys must be severity levels between 0 and 5 inclusive, got %s", key, level))
+		}
+	}
+	return
+}
+
+func resourceZabbixSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	d.SetId(settingsID)
+	return resourceZabbixSettingsUpdate(ctx, d, meta)
+}
+
+func resourceZabbixSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	zabbixVersion := getZabbixServerVersion(meta)
+	if !isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.2.0") {
+		return diag.Errorf("zabbix_settings requires Zabbix 5.2 or higher, server version is %s", zabbixVersion)
+	}
+
+	settings, err := getSettingsObject(api, "settings.get")
+	if err != nil {
+		return diagFromErr(err)
+	}
+	housekeeping, err := getSettingsObject(api, "housekeeping.get")
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	d.Set("default_theme", settings["default_theme"])
+	serverCheckInterval, _ := strconv.Atoi(settings["server_check_interval"])
+	d.Set("server_check_interval", serverCheckInterval)
+	d.Set("frontend_url", settings["url"])
+
+	// only the configured severities are managed, the others keep the server values
+	d.Set("severity_names", createTerraformSeverities(d.Get("severity_names").(map[string]interface{}), settings, "severity_name_"))
+	d.Set("severity_colors", createTerraformSeverities(d.Get("severity_colors").(map[string]interface{}), settings, "severity_color_"))
+
+	d.Set("history_housekeeping", housekeeping["hk_history_mode"] == "1")
+	d.Set("history_override", housekeeping["hk_history_global"] == "1")
+	d.Set("history_period", housekeeping["hk_history"])
+	d.Set("trends_housekeeping", housekeeping["hk_trends_mode"] == "1")
+	d.Set("trends_override", housekeeping["hk_trends_global"] == "1")
+	d.Set("trends_period", housekeeping["hk_trends"])
+
+	log.Printf("[DEBUG] Zabbix settings read, frontend url is %s\n", settings["url"])
+	return nil
+}
+
+func resourceZabbixSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	zabbixVersion := getZabbixServerVersion(meta)
+	if !isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.2.0") {
+		return diag.Errorf("zabbix_settings requires Zabbix 5.2 or higher, server version is %s", zabbixVersion)
+	}
+
+	settings := createSettingsParams(d, settingsFields)
+	for level, name := range d.Get("severity_names").(map[string]interface{}) {
+		settings["severity_name_"+level] = name
+	}
+	for level, color := range d.Get("severity_colors").(map[string]interface{}) {
+		settings["severity_color_"+level] = color
+	}
+	if len(settings) > 0 {
+		_, err := api.CallWithError("settings.update", settings)
+		if err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	housekeeping := createSettingsParams(d, housekeepingFields)
+	if len(housekeeping) > 0 {
+		_, err := api.CallWithError("housekeeping.update", housekeeping)
+		if err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	return resourceZabbixSettingsRead(ctx, d, meta)
+}
+
+// resourceZabbixSettingsDelete only removes the settings from the state,
+// the Zabbix server keeps its current configuration
+func resourceZabbixSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// getSettingsObject returns the properties of the settings or housekeeping
+// object as strings
+func getSettingsObject(api *zabbix.API, method string) (map[string]string, error) {
+	var object map[string]interface{}
+	err := api.CallWithErrorParse(method, zabbix.Params{"output": "extend"}, &object)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]string, len(object))
+	for property, value := range object {
+		if value != nil {
+			properties[property] = fmt.Sprint(value)
+		}
+	}
+	return properties, nil
+}
+
+// createSettingsParams returns the API parameters of the configured attributes in fields
+func createSettingsParams(d *schema.ResourceData, fields map[string]string) zabbix.Params {
+	params := zabbix.Params{}
+	for attribute, property := range fields {
+		value, ok := d.GetOkExists(attribute)
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case bool:
+			if v {
+				params[property] = "1"
+			} else {
+				params[property] = "0"
+			}
+		case int:
+			params[property] = strconv.Itoa(v)
+		default:
+			params[property] = v
+		}
+	}
+	return params
+}
+
+func createTerraformSeverities(configured map[string]interface{}, settings map[string]string, prefix string) map[string]interface{} {
+	severities := map[string]interface{}{}
+	for _, level := range severityLevels {
+		if _, ok := configured[level]; ok {
+			severities[level] = settings[prefix+level]
+		}
+	}
+	return severities
+}
@@ -0,0 +1,54 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccZabbixSettings_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfZabbixVersionLower(t, "5.2.0")
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixSettingsConfig("Not classified", "365d"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_settings.settings", "id", "settings"),
+					resource.TestCheckResourceAttr("zabbix_settings.settings", "severity_names.0", "Not classified"),
+					resource.TestCheckResourceAttr("zabbix_settings.settings", "severity_colors.5", "E45959"),
+					resource.TestCheckResourceAttr("zabbix_settings.settings", "history_housekeeping", "true"),
+					resource.TestCheckResourceAttr("zabbix_settings.settings", "trends_period", "365d"),
+				),
+			},
+			{
+				Config: testAccZabbixSettingsConfig("Unclassified", "180d"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_settings.settings", "severity_names.0", "Unclassified"),
+					resource.TestCheckResourceAttr("zabbix_settings.settings", "trends_period", "180d"),
+				),
+			},
+		},
+	})
+}
+
+func testAccZabbixSettingsConfig(severityName, trendsPeriod string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_settings" "settings" {
+			severity_names = {
+				"0" = "%s"
+			}
+			severity_colors = {
+				"5" = "E45959"
+			}
+
+			history_housekeeping = true
+			trends_housekeeping = true
+			trends_period = "%s"
+		}
+	`, severityName, trendsPeriod)
+}
@@ -0,0 +1,330 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// secondsPerWeek is the upper bound of the SLA schedule periods
+const secondsPerWeek = 604800
+
+// slaObject represent Zabbix SLA object
+// https://www.zabbix.com/documentation/current/manual/api/reference/sla/object
+type slaObject struct {
+	SLAID             string                `json:"slaid,omitempty"`
+	Name              string                `json:"name"`
+	Period            int                   `json:"period,string"`
+	SLO               string                `json:"slo"`
+	EffectiveDate     string                `json:"effective_date,omitempty"`
+	Timezone          string                `json:"timezone"`
+	Status            int                   `json:"status,string"`
+	Description       string                `json:"description"`
+	Schedule          []slaSchedule         `json:"schedule"`
+	ExcludedDowntimes []slaExcludedDowntime `json:"excluded_downtimes"`
+	ServiceTags       []problemTag          `json:"service_tags"`
+}
+
+type slaSchedule struct {
+	PeriodFrom int `json:"period_from,string"`
+	PeriodTo   int `json:"period_to,string"`
+}
+
+type slaExcludedDowntime struct {
+	Name       string `json:"name"`
+	PeriodFrom int64  `json:"period_from,string"`
+	PeriodTo   int64  `json:"period_to,string"`
+}
+
+func resourceZabbixSLA() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixSLACreate,
+		ReadContext:   resourceZabbixSLARead,
+		UpdateContext: resourceZabbixSLAUpdate,
+		DeleteContext: resourceZabbixSLADelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customizeDiffVersions("zabbix_sla", "6.0.0"),
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the SLA.",
+			},
+			"period": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Reporting period, 0 daily, 1 weekly, 2 monthly, 3 quarterly or 4 annually.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 4 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 4 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+			"slo": &schema.Schema{
+				Type:        schema.TypeFloat,
+				Required:    true,
+				Description: "Service level objective in percent.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(float64)
+					if v < 0 || v > 100 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 100 inclusive, got %g", key, v))
+					}
+					return
+				},
+			},
+			"effective_date": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Unix timestamp of the date the SLA becomes effective.",
+			},
+			"timezone": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "UTC",
+				Description: "Time zone of the reporting periods.",
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"schedule": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Weekly service times, the SLA is calculated 24x7 without schedule.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"period_from": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "Start of the period in seconds since the beginning of the week.",
+							ValidateFunc: validateSLASchedulePeriod,
+						},
+						"period_to": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "End of the period in seconds since the beginning of the week.",
+							ValidateFunc: validateSLASchedulePeriod,
+						},
+					},
+				},
+			},
+			"excluded_downtime": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Downtimes excluded from the SLA calculation.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"period_from": &schema.Schema{
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Unix timestamp of the start of the downtime.",
+						},
+						"period_to": &schema.Schema{
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Unix timestamp of the end of the downtime.",
+						},
+					},
+				},
+			},
+			"service_tag": &schema.Schema{
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Tags of the services included in the SLA.",
+				Elem:        schemaProblemTag(),
+			},
+		},
+	}
+}
+
+func validateSLASchedulePeriod(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(int)
+	if v < 0 || v > secondsPerWeek {
+		errs = append(errs, fmt.Errorf("%q, must be between 0 and %d inclusive, got %d", key, secondsPerWeek, v))
+	}
+	return
+}
+
+func resourceZabbixSLACreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	sla, err := createSLAObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return createRetry(ctx, d, meta, createSLA, *sla, resourceZabbixSLARead)
+}
+
+func resourceZabbixSLARead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	sla, err := getSLAByID(d.Id(), api)
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "SLA", err))
+	}
+
+	d.Set("name", sla.Name)
+	d.Set("period", sla.Period)
+	slo, _ := strconv.ParseFloat(sla.SLO, 64)
+	d.Set("slo", slo)
+	effectiveDate, _ := strconv.Atoi(sla.EffectiveDate)
+	d.Set("effective_date", effectiveDate)
+	d.Set("timezone", sla.Timezone)
+	d.Set("enabled", sla.Status == 1)
+	d.Set("description", sla.Description)
+
+	schedule := make([]interface{}, len(sla.Schedule))
+	for i, period := range sla.Schedule {
+		schedule[i] = map[string]interface{}{
+			"period_from": period.PeriodFrom,
+			"period_to":   period.PeriodTo,
+		}
+	}
+	d.Set("schedule", schedule)
+
+	downtimes := make([]interface{}, len(sla.ExcludedDowntimes))
+	for i, downtime := range sla.ExcludedDowntimes {
+		downtimes[i] = map[string]interface{}{
+			"name":        downtime.Name,
+			"period_from": int(downtime.PeriodFrom),
+			"period_to":   int(downtime.PeriodTo),
+		}
+	}
+	d.Set("excluded_downtime", downtimes)
+	d.Set("service_tag", flattenProblemTags(sla.ServiceTags))
+
+	log.Printf("[DEBUG] SLA name is %s\n", sla.Name)
+	return nil
+}
+
+func resourceZabbixSLAUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	sla, err := createSLAObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	sla.SLAID = d.Id()
+	return createRetry(ctx, d, meta, updateSLA, *sla, resourceZabbixSLARead)
+}
+
+func resourceZabbixSLADelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	_, err := api.CallWithError("sla.delete", []string{d.Id()})
+	return diagFromErr(err)
+}
+
+func createSLAObject(d *schema.ResourceData, zabbixVersion string) (*slaObject, error) {
+	if !isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.0.0") {
+		return nil, fmt.Errorf("zabbix_sla requires Zabbix 6.0 or higher, use good_sla on zabbix_service with server version %s", zabbixVersion)
+	}
+
+	sla := slaObject{
+		Name:              d.Get("name").(string),
+		Period:            d.Get("period").(int),
+		SLO:               strconv.FormatFloat(d.Get("slo").(float64), 'f', -1, 64),
+		Timezone:          d.Get("timezone").(string),
+		Status:            0,
+		Description:       d.Get("description").(string),
+		Schedule:          []slaSchedule{},
+		ExcludedDowntimes: []slaExcludedDowntime{},
+		ServiceTags:       createProblemTags(d.Get("service_tag").(*schema.Set)),
+	}
+	if d.Get("enabled").(bool) {
+		sla.Status = 1
+	}
+	if effectiveDate, ok := d.GetOk("effective_date"); ok {
+		sla.EffectiveDate = strconv.Itoa(effectiveDate.(int))
+	}
+
+	for _, p := range d.Get("schedule").(*schema.Set).List() {
+		period := p.(map[string]interface{})
+		schedule := slaSchedule{
+			PeriodFrom: period["period_from"].(int),
+			PeriodTo:   period["period_to"].(int),
+		}
+		if schedule.PeriodFrom >= schedule.PeriodTo {
+			return nil, attributeErrorf("schedule", "SLA schedule period_from must be lower than period_to, got %d and %d", schedule.PeriodFrom, schedule.PeriodTo)
+		}
+		sla.Schedule = append(sla.Schedule, schedule)
+	}
+
+	for _, dt := range d.Get("excluded_downtime").(*schema.Set).List() {
+		downtime := dt.(map[string]interface{})
+		excludedDowntime := slaExcludedDowntime{
+			Name:       downtime["name"].(string),
+			PeriodFrom: int64(downtime["period_from"].(int)),
+			PeriodTo:   int64(downtime["period_to"].(int)),
+		}
+		if excludedDowntime.PeriodFrom >= excludedDowntime.PeriodTo {
+			return nil, attributeErrorf("excluded_downtime", "SLA excluded downtime %s period_from must be lower than period_to", excludedDowntime.Name)
+		}
+		sla.ExcludedDowntimes = append(sla.ExcludedDowntimes, excludedDowntime)
+	}
+	return &sla, nil
+}
+
+func getSLAByID(id string, api *zabbix.API) (*slaObject, error) {
+	var slas []slaObject
+
+	err := api.CallWithErrorParse("sla.get", zabbix.Params{
+		"slaids":                  id,
+		"output":                  "extend",
+		"selectSchedule":          "extend",
+		"selectExcludedDowntimes": "extend",
+		"selectServiceTags":       "extend",
+	}, &slas)
+	if err != nil {
+		return nil, err
+	}
+	err = expectOneResult("SLA", id, len(slas))
+	if err != nil {
+		return nil, err
+	}
+	return &slas[0], nil
+}
+
+func createSLA(sla interface{}, api *zabbix.API) (id string, err error) {
+	response, err := api.CallWithError("sla.create", []slaObject{sla.(slaObject)})
+	if err != nil {
+		return
+	}
+
+	result := response.Result.(map[string]interface{})
+	id = result["slaids"].([]interface{})[0].(string)
+	return
+}
+
+func updateSLA(sla interface{}, api *zabbix.API) (id string, err error) {
+	zabbixSLA := sla.(slaObject)
+
+	_, err = api.CallWithError("sla.update", []slaObject{zabbixSLA})
+	if err != nil {
+		return
+	}
+	id = zabbixSLA.SLAID
+	return
+}
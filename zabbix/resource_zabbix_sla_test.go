@@ -0,0 +1,93 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccZabbixSLA_Basic(t *testing.T) {
+	strID := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfZabbixVersionLower(t, "6.0.0")
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixSLADestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixSLAConfig(strID, "99.5"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_sla.sla_test", "name", fmt.Sprintf("sla_%s", strID)),
+					resource.TestCheckResourceAttr("zabbix_sla.sla_test", "period", "2"),
+					resource.TestCheckResourceAttr("zabbix_sla.sla_test", "slo", "99.5"),
+					resource.TestCheckResourceAttr("zabbix_sla.sla_test", "enabled", "true"),
+					resource.TestCheckResourceAttr("zabbix_sla.sla_test", "schedule.#", "1"),
+					resource.TestCheckResourceAttr("zabbix_sla.sla_test", "excluded_downtime.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("zabbix_sla.sla_test", "service_tag.*", map[string]string{"tag": "team", "operator": "0", "value": "sre"}),
+				),
+			},
+			{
+				Config: testAccZabbixSLAConfig(strID, "99.9"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_sla.sla_test", "slo", "99.9"),
+				),
+			},
+			{
+				ResourceName:      "zabbix_sla.sla_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckZabbixSLADestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(*providerClient).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "zabbix_sla" {
+			continue
+		}
+
+		_, err := getSLAByID(rs.Primary.ID, api)
+		if err == nil {
+			return fmt.Errorf("SLA still exists %s", rs.Primary.ID)
+		}
+		if !isNotFoundError(err) {
+			return fmt.Errorf("expected a not found error, got : %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func testAccZabbixSLAConfig(strID, slo string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_sla" "sla_test" {
+			name = "sla_%s"
+			period = 2
+			slo = %s
+
+			schedule {
+				period_from = 32400
+				period_to = 61200
+			}
+
+			excluded_downtime {
+				name = "maintenance"
+				period_from = 1893456000
+				period_to = 1893463200
+			}
+
+			service_tag {
+				tag = "team"
+				value = "sre"
+			}
+		}
+	`, strID, slo)
+}
@@ -1,24 +1,69 @@
 package zabbix
 
 import (
+	"bytes"
+	"context"
 	"fmt"
-	"log"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// templateObject represent Zabbix template object with the properties
+// missing from zabbix.Template
+// https://www.zabbix.com/documentation/current/manual/api/reference/template/object
+type templateObject struct {
+	zabbix.Template
+	UUID          string     `json:"uuid,omitempty"`
+	VendorName    *string    `json:"vendor_name,omitempty"`
+	VendorVersion *string    `json:"vendor_version,omitempty"`
+	Tags          *[]tagData `json:"tags,omitempty"`
+	// Templates replaces the linked templates on update, it is sent even
+	// when empty so that the last linked template can be unlinked
+	Templates       *zabbix.TemplateIDs `json:"templates,omitempty"`
+	TemplatesClear  zabbix.TemplateIDs  `json:"templates_clear,omitempty"`
+	ParentTemplates []templateRef       `json:"parentTemplates,omitempty"`
+	Macros          []templateMacro     `json:"macros"`
+}
+
+// templateMacro represent Zabbix host macro object of a template
+// https://www.zabbix.com/documentation/current/manual/api/reference/usermacro/object#host_macro
+type templateMacro struct {
+	Macro       string  `json:"macro"`
+	Value       string  `json:"value"`
+	Type        *string `json:"type,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
 func resourceZabbixTemplate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceZabbixTemplateCreate,
-		Read:   resourceZabbixTemplateRead,
-		Exists: resourceZabbixTemplateExists,
-		Update: resourceZabbixTemplateUpdate,
-		Delete: resourceZabbixTemplateDelete,
+		CreateContext: resourceZabbixTemplateCreate,
+		ReadContext:   resourceZabbixTemplateRead,
+		UpdateContext: resourceZabbixTemplateUpdate,
+		DeleteContext: resourceZabbixTemplateDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceZabbixTemplateV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceZabbixTemplateStateUpgradeV0,
+				Version: 0,
+			},
+		},
+		CustomizeDiff: customizeDiffVersions("zabbix_template", "",
+			versionedAttribute{attribute: "tag", minVersion: "5.4.0"},
+			versionedAttribute{attribute: "uuid", minVersion: "5.4.0"},
+			versionedAttribute{attribute: "vendor_name", minVersion: "6.2.0"},
+			versionedAttribute{attribute: "vendor_version", minVersion: "6.2.0"},
+		),
 		Schema: map[string]*schema.Schema{
 			"host": &schema.Schema{
 				Type:        schema.TypeString,
@@ -29,7 +74,7 @@ func resourceZabbixTemplate() *schema.Resource {
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Required:    true,
-				Description: "ID of the Host Group.",
+				Description: "Names of the template groups, host groups before Zabbix 6.2.",
 			},
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
@@ -42,95 +87,312 @@ func resourceZabbixTemplate() *schema.Resource {
 				Description: "Description of the template.",
 			},
 			"macro": &schema.Schema{
-				Type:        schema.TypeMap,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeSet,
 				Optional:    true,
 				Description: "User macros for the template.",
+				Set:         hashTemplateMacro,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Name of the macro, without the {$ and } delimiters.",
+							ValidateFunc: validateMacroName,
+						},
+						"value": &schema.Schema{
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "",
+							Sensitive:        true,
+							Description:      "Value of the macro.",
+							DiffSuppressFunc: suppressUnknownSecretMacroValue,
+						},
+						"type": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+							Description: "Type of the macro (Zabbix 5.0+).",
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(int)
+								if v < 0 || v > 2 {
+									errs = append(errs, fmt.Errorf("%q, must be between 0 and 2 inclusive, got %d", key, v))
+								}
+								return
+							},
+						},
+						"description": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Description of the macro (Zabbix 4.4+).",
+						},
+					},
+				},
 			},
 			"linked_template": &schema.Schema{
-				Type:     schema.TypeSet,
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "IDs of the templates linked to the template.",
+			},
+			"clear_on_unlink": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Remove the entities inherited from the unlinked templates, otherwise they are kept as entities of the template.",
+			},
+			"tag": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tags of the template (Zabbix 5.4+).",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tag": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+						},
+					},
+				},
+			},
+			"uuid": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Universal unique identifier of the template, used to match templates on configuration import (Zabbix 5.4+).",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if !uuidRegexp.MatchString(v) {
+						errs = append(errs, fmt.Errorf("%q, must be 32 lowercase hexadecimal characters, got %s", key, v))
+					}
+					return
+				},
+			},
+			"vendor_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"vendor_version"},
+				Description:  "Name of the template vendor (Zabbix 6.2+).",
+			},
+			"vendor_version": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"vendor_name"},
+				Description:  "Version of the template (Zabbix 6.2+).",
+			},
+		},
+	}
+}
+
+// resourceZabbixTemplateV0 is the schema of the templates with the macros
+// stored as a map of names to values
+func resourceZabbixTemplateV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"host":        &schema.Schema{Type: schema.TypeString, Required: true},
+			"groups":      &schema.Schema{Type: schema.TypeSet, Elem: &schema.Schema{Type: schema.TypeString}, Required: true},
+			"name":        &schema.Schema{Type: schema.TypeString, Optional: true},
+			"description": &schema.Schema{Type: schema.TypeString, Optional: true},
+			"macro": &schema.Schema{
+				Type:     schema.TypeMap,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Optional: true,
 			},
+			"linked_template": &schema.Schema{Type: schema.TypeSet, Elem: &schema.Schema{Type: schema.TypeString}, Optional: true},
+			"clear_on_unlink": &schema.Schema{Type: schema.TypeBool, Optional: true, Default: true},
+			"tag": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tag":   &schema.Schema{Type: schema.TypeString, Required: true},
+						"value": &schema.Schema{Type: schema.TypeString, Optional: true, Default: ""},
+					},
+				},
+			},
+			"uuid":           &schema.Schema{Type: schema.TypeString, Optional: true, Computed: true},
+			"vendor_name":    &schema.Schema{Type: schema.TypeString, Optional: true},
+			"vendor_version": &schema.Schema{Type: schema.TypeString, Optional: true},
 		},
 	}
 }
 
-func createZabbixMacro(d *schema.ResourceData) zabbix.Macros {
-	var macros zabbix.Macros
+// resourceZabbixTemplateStateUpgradeV0 converts the macro map to text macro blocks
+func resourceZabbixTemplateStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	oldMacros, _ := rawState["macro"].(map[string]interface{})
+	macros := make([]interface{}, 0, len(oldMacros))
+	for name, value := range oldMacros {
+		macros = append(macros, map[string]interface{}{
+			"name":        name,
+			"value":       value,
+			"type":        0,
+			"description": "",
+		})
+	}
+	rawState["macro"] = macros
+	return rawState, nil
+}
+
+// hashTemplateMacro leaves the value of secret macros out of the hash, so
+// that a changed secret value is an in place update of the macro which
+// can be suppressed when the value is unknown
+func hashTemplateMacro(v interface{}) int {
+	macro := v.(map[string]interface{})
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%s-%d-%s-", macro["name"].(string), macro["type"].(int), macro["description"].(string)))
+	if macro["type"].(int) != macroTypeSecret {
+		buf.WriteString(macro["value"].(string))
+	}
+	return schema.HashString(buf.String())
+}
+
+// suppressUnknownSecretMacroValue ignores the configured value of an existing
+// secret macro when its value isn't known, e.g. after an import
+func suppressUnknownSecretMacroValue(k, old, new string, d *schema.ResourceData) bool {
+	oldType, newType := d.GetChange(strings.TrimSuffix(k, "value") + "type")
+	return oldType.(int) == macroTypeSecret && newType.(int) == macroTypeSecret && old == ""
+}
 
-	terraformMacros := d.Get("macro").(map[string]interface{})
-	for i, terraformMacro := range terraformMacros {
-		macro := zabbix.Macro{
-			MacroName: fmt.Sprintf("{$%s}", i),
-			Value:     terraformMacro.(string),
+func createTemplateMacros(d *schema.ResourceData, zabbixVersion string) ([]templateMacro, error) {
+	macros := []templateMacro{}
+
+	for _, m := range d.Get("macro").(*schema.Set).List() {
+		terraformMacro := m.(map[string]interface{})
+		macro := templateMacro{
+			Macro: fmt.Sprintf("{$%s}", terraformMacro["name"].(string)),
+			Value: terraformMacro["value"].(string),
+		}
+
+		macroType := terraformMacro["type"].(int)
+		if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.0.0") {
+			t := strconv.Itoa(macroType)
+			macro.Type = &t
+		} else if macroType != 0 {
+			return nil, attributeErrorf("macro", "Macro type requires Zabbix 5.0 or higher, server version is %s", zabbixVersion)
+		}
+
+		description := terraformMacro["description"].(string)
+		if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "4.4.0") {
+			macro.Description = &description
+		} else if description != "" {
+			return nil, attributeErrorf("macro", "Macro description requires Zabbix 4.4 or higher, server version is %s", zabbixVersion)
 		}
 		macros = append(macros, macro)
 	}
-	return macros
+	return macros, nil
 }
 
-func createLinkedTemplate(d *schema.ResourceData) zabbix.Templates {
-	var templates zabbix.Templates
+func createLinkedTemplate(d *schema.ResourceData) *zabbix.TemplateIDs {
+	templates := zabbix.TemplateIDs{}
 
 	terraformTemplates := d.Get("linked_template").(*schema.Set)
 	for _, terraformTemplate := range terraformTemplates.List() {
-		zabbixTemplate := zabbix.Template{
+		templates = append(templates, zabbix.TemplateID{
 			TemplateID: terraformTemplate.(string),
-		}
-		templates = append(templates, zabbixTemplate)
+		})
 	}
-	return templates
+	return &templates
 }
 
-func createTemplateObj(d *schema.ResourceData, api *zabbix.API) (*zabbix.Template, error) {
-	template := zabbix.Template{
-		Host:            d.Get("host").(string),
-		Name:            d.Get("name").(string),
-		Description:     d.Get("description").(string),
-		UserMacros:      createZabbixMacro(d),
-		LinkedTemplates: createLinkedTemplate(d),
+func createTemplateObj(d *schema.ResourceData, api *zabbix.API, zabbixVersion string) (*templateObject, error) {
+	template := templateObject{
+		Template: zabbix.Template{
+			Host:        d.Get("host").(string),
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+		},
+		Templates: createLinkedTemplate(d),
 	}
-	hostGroupIDs, err := getHostGroups(d, api)
+	groupIDs, err := getGroupIDsByName(api, getTemplateGroupAPI(zabbixVersion)+".get", d.Get("groups").(*schema.Set))
 	if err != nil {
 		return nil, err
 	}
-	template.Groups = make([]zabbix.HostGroup, len(hostGroupIDs))
-	for i, ID := range hostGroupIDs {
-		template.Groups[i].GroupID = ID.GroupID
+	template.Groups = make([]zabbix.HostGroup, len(groupIDs))
+	for i, ID := range groupIDs {
+		template.Groups[i].GroupID = ID
 	}
-	if template.UserMacros == nil {
-		template.UserMacros = zabbix.Macros{}
+	template.Macros, err = createTemplateMacros(d, zabbixVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.4.0") {
+		tags := createTagsData(d)
+		template.Tags = &tags
+		// the uuid is generated by the server when it is not configured
+		if d.HasChange("uuid") {
+			template.UUID = d.Get("uuid").(string)
+		}
+	} else if d.Get("tag").(*schema.Set).Len() > 0 || d.Get("uuid").(string) != "" {
+		return nil, fmt.Errorf("Template tags and uuid require Zabbix 5.4 or higher, server version is %s", zabbixVersion)
+	}
+
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.2.0") {
+		vendorName := d.Get("vendor_name").(string)
+		vendorVersion := d.Get("vendor_version").(string)
+		template.VendorName = &vendorName
+		template.VendorVersion = &vendorVersion
+	} else if d.Get("vendor_name").(string) != "" || d.Get("vendor_version").(string) != "" {
+		return nil, attributeErrorf("vendor_name", "Template vendor requires Zabbix 6.2 or higher, server version is %s", zabbixVersion)
 	}
 	return &template, nil
 }
 
-func resourceZabbixTemplateCreate(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func createTagsData(d *schema.ResourceData) []tagData {
+	tags := []tagData{}
+	for _, t := range d.Get("tag").(*schema.Set).List() {
+		tag := t.(map[string]interface{})
+		tags = append(tags, tagData{
+			Tag:   tag["tag"].(string),
+			Value: tag["value"].(string),
+		})
+	}
+	return tags
+}
+
+func resourceZabbixTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateTemplates()
+	api := meta.(*providerClient).api
 
-	template, err := createTemplateObj(d, api)
+	template, err := createTemplateObj(d, api, getZabbixServerVersion(meta))
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
-	return createRetry(d, meta, createTemplate, *template, resourceZabbixTemplateRead)
+	return createRetry(ctx, d, meta, createTemplate, *template, resourceZabbixTemplateRead)
 }
 
-func resourceZabbixTemplateRead(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+	zabbixVersion := getZabbixServerVersion(meta)
 
 	params := zabbix.Params{
-		"templateids":  d.Id(),
-		"output":       "extend",
-		"selectMacros": "extend",
+		"templateids":           d.Id(),
+		"output":                "extend",
+		"selectMacros":          "extend",
+		"selectParentTemplates": []string{"templateid", "host", "name"},
+	}
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.4.0") {
+		params["selectTags"] = "extend"
 	}
-	templates, err := api.TemplatesGet(params)
+	var templates []templateObject
+	err := api.CallWithErrorParse("template.get", params, &templates)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	if len(templates) != 1 {
-		log.Printf("[DEBUG] Expected one template with id %s and got %#v", d.Id(), templates)
-		return fmt.Errorf("Expected one template with id %s and got %d templates", d.Id(), len(templates))
+	err = expectOneResult("Template", d.Id(), len(templates))
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "Template", err))
 	}
 
 	template := templates[0]
@@ -139,83 +401,129 @@ func resourceZabbixTemplateRead(d *schema.ResourceData, meta interface{}) error
 		d.Set("name", template.Name)
 	}
 	d.Set("description", template.Description)
+	d.Set("uuid", template.UUID)
+	if template.VendorName != nil {
+		d.Set("vendor_name", *template.VendorName)
+	}
+	if template.VendorVersion != nil {
+		d.Set("vendor_version", *template.VendorVersion)
+	}
+	if template.Tags != nil {
+		terraformTags := make([]interface{}, len(*template.Tags))
+		for i, tag := range *template.Tags {
+			terraformTags[i] = map[string]interface{}{
+				"tag":   tag.Tag,
+				"value": tag.Value,
+			}
+		}
+		d.Set("tag", terraformTags)
+	}
 
-	terraformMacros, err := createTerraformMacro(template)
+	terraformMacros, err := createTerraformMacro(d, template.Macros)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	d.Set("macro", terraformMacros)
+	d.Set("linked_template", createTerraformLinkedTemplate(template.ParentTemplates))
 
-	terraformGroups, err := createTerraformTemplateGroup(d, api)
+	terraformGroups, err := createTerraformTemplateGroup(d, api, zabbixVersion)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	d.Set("groups", terraformGroups)
 	return nil
 }
 
-func resourceZabbixTemplateExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	api := meta.(*zabbix.API)
+func resourceZabbixTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateTemplates()
+	api := meta.(*providerClient).api
 
-	_, err := api.TemplateGetByID(d.Id())
+	template, err := createTemplateObj(d, api, getZabbixServerVersion(meta))
 	if err != nil {
-		if strings.Contains(err.Error(), "Expected exactly one result") {
-			log.Printf("[DEBUG] Template with id %s doesn't exist", d.Id())
-			return false, nil
-		}
-		return false, err
+		return diagFromErr(err)
 	}
-	return true, nil
-}
-
-func resourceZabbixTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
-
-	template, err := createTemplateObj(d, api)
-	if err != nil {
-		return err
+	// templates missing from linked_template are unlinked by the update,
+	// templates_clear also removes the entities they provided
+	if d.Get("clear_on_unlink").(bool) {
+		template.TemplatesClear = getUnlinkedTemplate(d)
 	}
-	template.TemplatesClear = getUnlinkedTemplate(d)
 	template.TemplateID = d.Id()
 
-	return createRetry(d, meta, updateTemplate, *template, resourceZabbixTemplateRead)
+	return createRetry(ctx, d, meta, updateTemplate, *template, resourceZabbixTemplateRead)
 }
 
-func resourceZabbixTemplateDelete(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateTemplates()
+	api := meta.(*providerClient).api
 
-	return api.TemplatesDeleteByIds([]string{d.Id()})
+	return diagFromErr(api.TemplatesDeleteByIds([]string{d.Id()}))
 }
 
-func createTerraformMacro(template zabbix.Template) (map[string]interface{}, error) {
-	terraformMacros := make(map[string]interface{}, len(template.UserMacros))
+func createTerraformMacro(d *schema.ResourceData, macros []templateMacro) ([]interface{}, error) {
+	// the value of secret macros can't be read back, the known values are kept
+	secretValues := map[string]string{}
+	for _, m := range d.Get("macro").(*schema.Set).List() {
+		macro := m.(map[string]interface{})
+		secretValues[macro["name"].(string)] = macro["value"].(string)
+	}
+
+	terraformMacros := make([]interface{}, len(macros))
+	for i, macro := range macros {
+		name, err := getTerraformMacroName(macro.Macro)
+		if err != nil {
+			return nil, err
+		}
 
-	for _, macro := range template.UserMacros {
-		var name string
-		if noPrefix := strings.Split(macro.MacroName, "{$"); len(noPrefix) == 2 {
-			name = noPrefix[1]
-		} else {
-			return nil, fmt.Errorf("Invalid macro name \"%s\"", macro.MacroName)
+		terraformMacro := map[string]interface{}{
+			"name":        name,
+			"value":       macro.Value,
+			"type":        0,
+			"description": "",
 		}
-		if noSuffix := strings.Split(name, "}"); len(noSuffix) == 2 {
-			name = noSuffix[0]
-		} else {
-			return nil, fmt.Errorf("Invalid macro name \"%s\"", macro.MacroName)
+		if macro.Type != nil {
+			terraformMacro["type"], _ = strconv.Atoi(*macro.Type)
 		}
-		terraformMacros[name] = macro.Value
+		if macro.Description != nil {
+			terraformMacro["description"] = *macro.Description
+		}
+		if terraformMacro["type"] == macroTypeSecret {
+			terraformMacro["value"] = secretValues[name]
+		}
+		terraformMacros[i] = terraformMacro
 	}
 	return terraformMacros, nil
 }
 
-func createTerraformTemplateGroup(d *schema.ResourceData, api *zabbix.API) ([]string, error) {
+// getTerraformMacroName strips the "{$" and "}" delimiters of a macro name
+func getTerraformMacroName(macroName string) (string, error) {
+	var name string
+	if noPrefix := strings.Split(macroName, "{$"); len(noPrefix) == 2 {
+		name = noPrefix[1]
+	} else {
+		return "", fmt.Errorf("Invalid macro name \"%s\"", macroName)
+	}
+	if noSuffix := strings.Split(name, "}"); len(noSuffix) == 2 {
+		name = noSuffix[0]
+	} else {
+		return "", fmt.Errorf("Invalid macro name \"%s\"", macroName)
+	}
+	return name, nil
+}
+
+func createTerraformTemplateGroup(d *schema.ResourceData, api *zabbix.API, zabbixVersion string) ([]string, error) {
 	params := zabbix.Params{
 		"output": "extend",
-		"hostids": []string{
-			d.Id(),
-		},
+	}
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.2.0") {
+		params["templateids"] = []string{d.Id()}
+	} else {
+		params["hostids"] = []string{d.Id()}
 	}
 
-	groups, err := api.HostGroupsGet(params)
+	var groups []zabbix.HostGroup
+	err := api.CallWithErrorParse(getTemplateGroupAPI(zabbixVersion)+".get", params, &groups)
 	if err != nil {
 		return nil, err
 	}
@@ -227,20 +535,20 @@ func createTerraformTemplateGroup(d *schema.ResourceData, api *zabbix.API) ([]st
 	return groupNames, nil
 }
 
-func createTerraformLinkedTemplate(template zabbix.Template) []string {
-	var terraformTemplates []string
+func createTerraformLinkedTemplate(linkedTemplates []templateRef) []string {
+	terraformTemplates := make([]string, len(linkedTemplates))
 
-	for _, linkedTemplate := range template.LinkedTemplates {
-		terraformTemplates = append(terraformTemplates, linkedTemplate.TemplateID)
+	for i, linkedTemplate := range linkedTemplates {
+		terraformTemplates[i] = linkedTemplate.TemplateID
 	}
 	return terraformTemplates
 }
 
-func getUnlinkedTemplate(d *schema.ResourceData) zabbix.Templates {
+func getUnlinkedTemplate(d *schema.ResourceData) zabbix.TemplateIDs {
 	before, after := d.GetChange("linked_template")
 	beforeID := before.(*schema.Set).List()
 	afterID := after.(*schema.Set).List()
-	var unlinkID zabbix.Templates
+	var unlinkID zabbix.TemplateIDs
 
 	for _, l := range beforeID {
 		present := false
@@ -250,30 +558,30 @@ func getUnlinkedTemplate(d *schema.ResourceData) zabbix.Templates {
 			}
 		}
 		if !present {
-			unlinkID = append(unlinkID, zabbix.Template{TemplateID: l.(string)})
+			unlinkID = append(unlinkID, zabbix.TemplateID{TemplateID: l.(string)})
 		}
 	}
 	return unlinkID
 }
 
 func createTemplate(template interface{}, api *zabbix.API) (id string, err error) {
-	templates := zabbix.Templates{template.(zabbix.Template)}
-
-	err = api.TemplatesCreate(templates)
+	response, err := api.CallWithError("template.create", []templateObject{template.(templateObject)})
 	if err != nil {
 		return
 	}
-	id = templates[0].TemplateID
+
+	result := response.Result.(map[string]interface{})
+	id = result["templateids"].([]interface{})[0].(string)
 	return
 }
 
 func updateTemplate(template interface{}, api *zabbix.API) (id string, err error) {
-	templates := zabbix.Templates{template.(zabbix.Template)}
+	zabbixTemplate := template.(templateObject)
 
-	err = api.TemplatesUpdate(templates)
+	_, err = api.CallWithError("template.update", []templateObject{zabbixTemplate})
 	if err != nil {
 		return
 	}
-	id = templates[0].TemplateID
+	id = zabbixTemplate.TemplateID
 	return
 }
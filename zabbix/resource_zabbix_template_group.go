@@ -0,0 +1,146 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceZabbixTemplateGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixTemplateGroupCreate,
+		ReadContext:   resourceZabbixTemplateGroupRead,
+		UpdateContext: resourceZabbixTemplateGroupUpdate,
+		DeleteContext: resourceZabbixTemplateGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceZabbixTemplateGroupImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the template group.",
+			},
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// getTemplateGroupAPI returns the API object of template groups: template groups
+// are only split from host groups from Zabbix 6.2
+func getTemplateGroupAPI(zabbixVersion string) string {
+	if isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.2.0") {
+		return "templategroup"
+	}
+	return "hostgroup"
+}
+
+func resourceZabbixTemplateGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateHostGroups()
+	api := meta.(*providerClient).api
+
+	response, err := api.CallWithError(getTemplateGroupAPI(getZabbixServerVersion(meta))+".create", []zabbix.HostGroup{
+		{Name: d.Get("name").(string)},
+	})
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	result := response.Result.(map[string]interface{})
+	groupID := result["groupids"].([]interface{})[0].(string)
+
+	log.Printf("[DEBUG] Created template group, id is %s", groupID)
+
+	d.Set("group_id", groupID)
+	d.SetId(groupID)
+	return nil
+}
+
+func resourceZabbixTemplateGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	group, err := getTemplateGroupByID(d.Id(), api, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "Template group", err))
+	}
+
+	d.Set("name", group.Name)
+	d.Set("group_id", group.GroupID)
+	return nil
+}
+
+func resourceZabbixTemplateGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateHostGroups()
+	api := meta.(*providerClient).api
+
+	_, err := api.CallWithError(getTemplateGroupAPI(getZabbixServerVersion(meta))+".update", []zabbix.HostGroup{
+		{GroupID: d.Id(), Name: d.Get("name").(string)},
+	})
+	return diagFromErr(err)
+}
+
+func resourceZabbixTemplateGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateHostGroups()
+	api := meta.(*providerClient).api
+
+	_, err := api.CallWithError(getTemplateGroupAPI(getZabbixServerVersion(meta))+".delete", []string{d.Id()})
+	return diagFromErr(err)
+}
+
+// resourceZabbixTemplateGroupImport accepts the ID or the name of the group,
+// the name helps moving host groups of templates to template groups after an
+// upgrade to Zabbix 6.2 as the upgrade gives them new IDs
+func resourceZabbixTemplateGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.Atoi(d.Id()); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	var groups []zabbix.HostGroup
+	err := api.CallWithErrorParse(getTemplateGroupAPI(getZabbixServerVersion(meta))+".get", zabbix.Params{
+		"output": "extend",
+		"filter": map[string]interface{}{
+			"name": d.Id(),
+		},
+	}, &groups)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) != 1 {
+		return nil, fmt.Errorf("Expected one template group named %s and got %d", d.Id(), len(groups))
+	}
+
+	d.SetId(groups[0].GroupID)
+	return []*schema.ResourceData{d}, nil
+}
+
+func getTemplateGroupByID(id string, api *zabbix.API, zabbixVersion string) (*zabbix.HostGroup, error) {
+	var groups []zabbix.HostGroup
+
+	err := api.CallWithErrorParse(getTemplateGroupAPI(zabbixVersion)+".get", zabbix.Params{
+		"output":   "extend",
+		"groupids": id,
+	}, &groups)
+	if err != nil {
+		return nil, err
+	}
+	err = expectOneResult("Template group", id, len(groups))
+	if err != nil {
+		return nil, err
+	}
+	return &groups[0], nil
+}
@@ -0,0 +1,66 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccZabbixTemplateGroup_Basic(t *testing.T) {
+	groupName := fmt.Sprintf("template_group_%s", acctest.RandString(5))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixTemplateGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixTemplateGroupConfig(groupName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_template_group.zabbix", "name", groupName),
+					resource.TestCheckResourceAttrPair("zabbix_template_group.zabbix", "group_id", "zabbix_template_group.zabbix", "id"),
+				),
+			},
+			{
+				Config: testAccZabbixTemplateGroupConfig(groupName + "_updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_template_group.zabbix", "name", groupName+"_updated"),
+				),
+			},
+			{
+				ResourceName:      "zabbix_template_group.zabbix",
+				ImportState:       true,
+				ImportStateId:     groupName + "_updated",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckZabbixTemplateGroupDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "zabbix_template_group" {
+			continue
+		}
+
+		_, err := getTemplateGroupByID(rs.Primary.ID, testAccProvider.Meta().(*providerClient).api, getZabbixServerVersion(testAccProvider.Meta()))
+		if err == nil {
+			return fmt.Errorf("Template group still exists")
+		}
+		if !isNotFoundError(err) {
+			return fmt.Errorf("expected a not found error, got : %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func testAccZabbixTemplateGroupConfig(groupName string) string {
+	return fmt.Sprintf(`
+		resource "zabbix_template_group" "zabbix" {
+			name = "%s"
+		}`, groupName,
+	)
+}
@@ -1,21 +1,22 @@
 package zabbix
 
 import (
+	"context"
 	"log"
 
 	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceZabbixTemplateLink() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceZabbixTemplateLinkCreate,
-		Read:   resourceZabbixTemplateLinkRead,
-		Exists: resourceZabbixTemplateLinkExists,
-		Update: resourceZabbixTemplateLinkUpdate,
-		Delete: resourceZabbixTemplateLinkDelete,
+		CreateContext: resourceZabbixTemplateLinkCreate,
+		ReadContext:   resourceZabbixTemplateLinkRead,
+		UpdateContext: resourceZabbixTemplateLinkUpdate,
+		DeleteContext: resourceZabbixTemplateLinkDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"template_id": &schema.Schema{
@@ -86,28 +87,37 @@ func schemaTemplatelldRule() *schema.Resource {
 	}
 }
 
-func resourceZabbixTemplateLinkCreate(d *schema.ResourceData, meta interface{}) error {
-	return resourceZabbixTemplateLinkRead(d, meta)
+func resourceZabbixTemplateLinkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceZabbixTemplateLinkRead(ctx, d, meta)
 }
 
-func resourceZabbixTemplateLinkRead(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixTemplateLinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	// the link is gone with its template, the id is empty on create
+	if d.Id() != "" {
+		_, err := api.TemplateGetByID(d.Id())
+		if err != nil {
+			return diagFromErr(removeFromStateIfNotFound(d, "Template", err))
+		}
+	}
 
 	itemsTerraform, err := getTerraformTemplateItems(d, api)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	d.Set("item", itemsTerraform)
 
 	triggersTerraform, err := getTerraformTemplateTriggers(d, api)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	d.Set("trigger", triggersTerraform)
 
 	lldRulesTerraform, err := getTerraformTemplateLLDRules(d, api)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	d.Set("lld_rule", lldRulesTerraform)
 
@@ -115,29 +125,27 @@ func resourceZabbixTemplateLinkRead(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func resourceZabbixTemplateLinkExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	return true, nil
-}
-
-func resourceZabbixTemplateLinkUpdate(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixTemplateLinkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateItems()
+	api := meta.(*providerClient).api
 
 	err := updateZabbixTemplateItems(d, api)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	err = updateZabbixTemplateTriggers(d, api)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	err = updateZabbixTemplateDiscoveryRules(d, api)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	return resourceZabbixTemplateLinkRead(d, meta)
+	return resourceZabbixTemplateLinkRead(ctx, d, meta)
 }
 
-func resourceZabbixTemplateLinkDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceZabbixTemplateLinkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
 
@@ -154,13 +154,13 @@ func testAccZabbixTemplateLinkConfig(groupName, templateName string) string {
 	return fmt.Sprintf(`
 		data "zabbix_server" "test" {}
 
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s"
-			groups = [ zabbix_host_group.zabbix.name ]
+			groups = [ zabbix_template_group.zabbix.name ]
 			name = "display name for template test %s"
 	  	}
 
@@ -193,13 +193,13 @@ func testAccZabbixTemplateLinkConfig(groupName, templateName string) string {
 
 func testAccZabbixTemplateLinkDeleteTrigger(groupName, templateName string) string {
 	return fmt.Sprintf(`
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name for template test %s"
 	  	}
 
@@ -221,13 +221,13 @@ func testAccZabbixTemplateLinkDeleteTrigger(groupName, templateName string) stri
 
 func testAccZabbixTemplateLinkDeleteItem(groupName, templateName string) string {
 	return fmt.Sprintf(`
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name for template test %s"
 		  }
 
@@ -239,7 +239,7 @@ func testAccZabbixTemplateLinkDeleteItem(groupName, templateName string) string
 
 func testAccZabbixTemplateLinkCreateServerItem(template zabbix.Template, item *zabbix.Item) func() {
 	return func() {
-		api := testAccProvider.Meta().(*zabbix.API)
+		api := testAccProvider.Meta().(*providerClient).api
 
 		item.HostID = template.TemplateID
 		items := zabbix.Items{*item}
@@ -253,7 +253,7 @@ func testAccZabbixTemplateLinkCreateServerItem(template zabbix.Template, item *z
 
 func testAccZabbixTemplateLinkCreateServerTrigger(template zabbix.Template, item zabbix.Item, trigger *zabbix.Trigger) func() {
 	return func() {
-		api := testAccProvider.Meta().(*zabbix.API)
+		api := testAccProvider.Meta().(*providerClient).api
 
 		trigger.Expression = fmt.Sprintf("{%s:%s.last()} = 0", template.Host, item.Key)
 		triggers := zabbix.Triggers{*trigger}
@@ -277,7 +277,7 @@ func testAccCheckTemplateExists(n string, template *zabbix.Template) resource.Te
 			return fmt.Errorf("Not found: %s", n)
 		}
 
-		api := testAccProvider.Meta().(*zabbix.API)
+		api := testAccProvider.Meta().(*providerClient).api
 		templates, err := api.TemplateGetByID(rs.Primary.ID)
 		if err != nil {
 			return err
@@ -289,7 +289,7 @@ func testAccCheckTemplateExists(n string, template *zabbix.Template) resource.Te
 
 func testAccCheckTemplateServerItemDelete(item *zabbix.Item) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		api := testAccProvider.Meta().(*zabbix.API)
+		api := testAccProvider.Meta().(*providerClient).api
 
 		_, err := api.ItemGetByID(item.ItemID)
 		if err == nil {
@@ -306,7 +306,7 @@ func testAccCheckTemplateServerItemDelete(item *zabbix.Item) resource.TestCheckF
 
 func testAccCheckTemplateServerTriggerDelete(trigger *zabbix.Trigger) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		api := testAccProvider.Meta().(*zabbix.API)
+		api := testAccProvider.Meta().(*providerClient).api
 
 		_, err := api.TriggerGetByID(trigger.TriggerID)
 		if err == nil {
@@ -1,7 +1,9 @@
 package zabbix
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/claranet/go-zabbix-api"
@@ -26,8 +28,8 @@ func TestAccZabbixTemplate_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("template_%s", strID)),
 					resource.TestCheckResourceAttr(resourceName, "host", fmt.Sprintf("template_%s", strID)),
 					resource.TestCheckResourceAttr(resourceName, "groups.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "macro.MACRO1", "value1"),
-					resource.TestCheckResourceAttr(resourceName, "macro.MACRO2", "value2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "MACRO1", "value": "value1"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "MACRO2", "value": "value2"}),
 				),
 			},
 			{
@@ -37,8 +39,8 @@ func TestAccZabbixTemplate_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("update_template_%s", strID)),
 					resource.TestCheckResourceAttr(resourceName, "host", fmt.Sprintf("update_template_%s", strID)),
 					resource.TestCheckResourceAttr(resourceName, "groups.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "macro.MACRO1", "update_value1"),
-					resource.TestCheckResourceAttr(resourceName, "macro.UPDATE_MACRO2", "value2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "MACRO1", "value": "update_value1"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "UPDATE_MACRO2", "value": "value2"}),
 				),
 			},
 		},
@@ -58,39 +60,105 @@ func TestAccZabbixTemplate_UserMacro(t *testing.T) {
 				Config: testAccZabbixTemplateUserMacro(strID),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "host", fmt.Sprintf("template_%s", strID)),
-					resource.TestCheckResourceAttr(resourceName, "macro.%", "1"),
-					resource.TestCheckResourceAttr(resourceName, "macro.MYMACRO1", "value1"),
+					resource.TestCheckResourceAttr(resourceName, "macro.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "MYMACRO1", "value": "value1"}),
 				),
 			},
 			{
 				Config: testAccZabbixTemplateUserMacroAdd(strID),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "host", fmt.Sprintf("template_%s", strID)),
-					resource.TestCheckResourceAttr(resourceName, "macro.%", "2"),
-					resource.TestCheckResourceAttr(resourceName, "macro.MYMACRO1", "value1"),
-					resource.TestCheckResourceAttr(resourceName, "macro.MYMACRO2", "value2"),
+					resource.TestCheckResourceAttr(resourceName, "macro.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "MYMACRO1", "value": "value1"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "MYMACRO2", "value": "value2"}),
 				),
 			},
 			{
 				Config: testAccZabbixTemplateUserMacroUpdate(strID),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "host", fmt.Sprintf("template_%s", strID)),
-					resource.TestCheckResourceAttr(resourceName, "macro.%", "2"),
-					resource.TestCheckResourceAttr(resourceName, "macro.MYMACRO1", "value3"),
-					resource.TestCheckResourceAttr(resourceName, "macro.MYMACRO3", "value2"),
+					resource.TestCheckResourceAttr(resourceName, "macro.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "MYMACRO1", "value": "value3"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "MYMACRO3", "value": "value2"}),
 				),
 			},
 			{
 				Config: testAccZabbixTemplateUserMacroDelete(strID),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "host", fmt.Sprintf("template_%s", strID)),
-					resource.TestCheckResourceAttr(resourceName, "macro.%", "0"),
+					resource.TestCheckResourceAttr(resourceName, "macro.#", "0"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccZabbixTemplate_SecretMacro(t *testing.T) {
+	resourceName := "zabbix_template.template_test"
+	strID := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfZabbixVersionLower(t, "5.0.0")
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixTemplateSecretMacro(strID, "secret1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "macro.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "PASSWORD", "value": "secret1", "type": "1", "description": "database password"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "USER", "value": "zabbix", "type": "0"}),
+				),
+			},
+			{
+				Config: testAccZabbixTemplateSecretMacro(strID, "secret2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "macro.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "macro.*", map[string]string{"name": "PASSWORD", "value": "secret2", "type": "1"}),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"macro"},
+			},
+		},
+	})
+}
+
+func TestResourceZabbixTemplateStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"host": "template",
+		"macro": map[string]interface{}{
+			"MACRO1": "value1",
+		},
+	}
+
+	state, err := resourceZabbixTemplateStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"name":        "MACRO1",
+			"value":       "value1",
+			"type":        0,
+			"description": "",
+		},
+	}
+	if !reflect.DeepEqual(state["macro"], expected) {
+		t.Fatalf("Expected macros %#v, got %#v", expected, state["macro"])
+	}
+	if state["host"] != "template" {
+		t.Fatalf("Expected host template, got %v", state["host"])
+	}
+}
+
 func TestAccZabbixTemplate_linkedTemplate(t *testing.T) {
 	resource1Name := "zabbix_template.template_test_1"
 	resource2Name := "zabbix_template.template_test_2"
@@ -109,8 +177,14 @@ func TestAccZabbixTemplate_linkedTemplate(t *testing.T) {
 					resource.TestCheckResourceAttr(resource2Name, "host", fmt.Sprintf("template_%s_2", strID)),
 					resource.TestCheckResourceAttr(resource2Name, "groups.#", "1"),
 					resource.TestCheckResourceAttr(resource2Name, "linked_template.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resource2Name, "linked_template.*", resource1Name, "id"),
 				),
 			},
+			{
+				ResourceName:      resource2Name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccZabbixTemplateLinkedTemplateDelete(strID),
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -125,8 +199,101 @@ func TestAccZabbixTemplate_linkedTemplate(t *testing.T) {
 	})
 }
 
+func TestAccZabbixTemplate_unlinkTemplate(t *testing.T) {
+	resourceName := "zabbix_template.template_test_2"
+
+	for _, tc := range []struct {
+		clearOnUnlink bool
+		itemCount     int
+	}{
+		{clearOnUnlink: true, itemCount: 0},
+		{clearOnUnlink: false, itemCount: 1},
+	} {
+		strID := acctest.RandString(5)
+		resource.Test(t, resource.TestCase{
+			PreCheck:     func() { testAccPreCheck(t) },
+			Providers:    testAccProviders,
+			CheckDestroy: testAccCheckZabbixTemplateDestroy,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccZabbixTemplateUnlinkTemplate(strID, tc.clearOnUnlink, true),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "linked_template.#", "1"),
+						testAccCheckZabbixTemplateItemCount(resourceName, 1),
+					),
+				},
+				{
+					Config: testAccZabbixTemplateUnlinkTemplate(strID, tc.clearOnUnlink, false),
+					Check: resource.ComposeAggregateTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "linked_template.#", "0"),
+						testAccCheckZabbixTemplateItemCount(resourceName, tc.itemCount),
+					),
+				},
+			},
+		})
+	}
+}
+
+func TestAccZabbixTemplate_TagsAndUUID(t *testing.T) {
+	resourceName := "zabbix_template.template_test"
+	strID := acctest.RandString(5)
+	uuid := "0123456789abcdef0123456789abcdef"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccSkipIfZabbixVersionLower(t, "5.4.0")
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixTemplateTags(strID, uuid, "linux"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "uuid", uuid),
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "tag.*", map[string]string{"tag": "os", "value": "linux"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "tag.*", map[string]string{"tag": "managed", "value": ""}),
+				),
+			},
+			{
+				Config: testAccZabbixTemplateTags(strID, uuid, "windows"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "uuid", uuid),
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "tag.*", map[string]string{"tag": "os", "value": "windows"}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckZabbixTemplateItemCount(resourceName string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		api := testAccProvider.Meta().(*providerClient).api
+		items, err := api.ItemsGet(zabbix.Params{"hostids": rs.Primary.ID})
+		if err != nil {
+			return err
+		}
+		if len(items) != count {
+			return fmt.Errorf("Expected %d items on template %s, got %d", count, rs.Primary.ID, len(items))
+		}
+		return nil
+	}
+}
+
 func testAccCheckZabbixTemplateDestroy(s *terraform.State) error {
-	api := testAccProvider.Meta().(*zabbix.API)
+	api := testAccProvider.Meta().(*providerClient).api
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "zabbix_template" {
@@ -149,18 +316,23 @@ func testAccCheckZabbixTemplateDestroy(s *terraform.State) error {
 
 func testAccZabbixTemplateSimpleConfig(strID string) string {
 	return fmt.Sprintf(`
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 		name = "template_%s"
 		description = "test_template_description"
-		macro = {
-		  MACRO1 = "value1"
-		  MACRO2 = "value2"
+		macro {
+			name = "MACRO1"
+			value = "value1"
+		}
+
+		macro {
+			name = "MACRO2"
+			value = "value2"
 		}
 	}
 	`, strID, strID, strID)
@@ -168,18 +340,23 @@ func testAccZabbixTemplateSimpleConfig(strID string) string {
 
 func testAccZabbixTemplateSimpleUpdate(strID string) string {
 	return fmt.Sprintf(`
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "update_template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 		name = "update_template_%s"
 		description = "update_test_template_description"
-		macro = {
-		  MACRO1 = "update_value1"
-		  UPDATE_MACRO2 = "value2"
+		macro {
+			name = "MACRO1"
+			value = "update_value1"
+		}
+
+		macro {
+			name = "UPDATE_MACRO2"
+			value = "value2"
 		}
 	}
 	`, strID, strID, strID)
@@ -187,18 +364,18 @@ func testAccZabbixTemplateSimpleUpdate(strID string) string {
 
 func testAccZabbixTemplateLinkedTemplate(strID string) string {
 	return fmt.Sprintf(`
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test_1" {
 		host = "template_%s_1"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 	}
 
 	resource "zabbix_template" "template_test_2" {
 		host = "template_%s_2"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 		linked_template = ["${zabbix_template.template_test_1.id}"]
 	}
 	`, strID, strID, strID)
@@ -206,18 +383,18 @@ func testAccZabbixTemplateLinkedTemplate(strID string) string {
 
 func testAccZabbixTemplateLinkedTemplateDelete(strID string) string {
 	return fmt.Sprintf(`
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test_1" {
 		host = "template_%s_1"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 	}
 
 	resource "zabbix_template" "template_test_2" {
 		host = "template_%s_2"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 		linked_template = []
 	}
 	`, strID, strID, strID)
@@ -225,15 +402,16 @@ func testAccZabbixTemplateLinkedTemplateDelete(strID string) string {
 
 func testAccZabbixTemplateUserMacro(strID string) string {
 	return fmt.Sprintf(`
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
-		macro = {
-			MYMACRO1 = "value1"
+		groups = ["${zabbix_template_group.host_group_test.name}"]
+		macro {
+			name = "MYMACRO1"
+			value = "value1"
 		}
 	}
 	`, strID, strID)
@@ -241,16 +419,21 @@ func testAccZabbixTemplateUserMacro(strID string) string {
 
 func testAccZabbixTemplateUserMacroAdd(strID string) string {
 	return fmt.Sprintf(`
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
-		macro = {
-			MYMACRO1 = "value1"
-			MYMACRO2 = "value2"
+		groups = ["${zabbix_template_group.host_group_test.name}"]
+		macro {
+			name = "MYMACRO1"
+			value = "value1"
+		}
+
+		macro {
+			name = "MYMACRO2"
+			value = "value2"
 		}
 	}
 	`, strID, strID)
@@ -258,16 +441,21 @@ func testAccZabbixTemplateUserMacroAdd(strID string) string {
 
 func testAccZabbixTemplateUserMacroUpdate(strID string) string {
 	return fmt.Sprintf(`
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
-		macro = {
-			MYMACRO1 = "value3"
-			MYMACRO3 = "value2"
+		groups = ["${zabbix_template_group.host_group_test.name}"]
+		macro {
+			name = "MYMACRO1"
+			value = "value3"
+		}
+
+		macro {
+			name = "MYMACRO3"
+			value = "value2"
 		}
 	}
 	`, strID, strID)
@@ -275,13 +463,96 @@ func testAccZabbixTemplateUserMacroUpdate(strID string) string {
 
 func testAccZabbixTemplateUserMacroDelete(strID string) string {
 	return fmt.Sprintf(`
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 	}
 	`, strID, strID)
 }
+
+func testAccZabbixTemplateTags(strID, uuid, os string) string {
+	return fmt.Sprintf(`
+	resource "zabbix_template_group" "template_group_test" {
+		name = "template_group_%s"
+	}
+
+	resource "zabbix_template" "template_test" {
+		host = "template_%s"
+		groups = [zabbix_template_group.template_group_test.name]
+		uuid = "%s"
+
+		tag {
+			tag = "os"
+			value = "%s"
+		}
+
+		tag {
+			tag = "managed"
+		}
+	}`, strID, strID, uuid, os)
+}
+
+func testAccZabbixTemplateUnlinkTemplate(strID string, clearOnUnlink, linked bool) string {
+	linkedTemplate := "[]"
+	if linked {
+		linkedTemplate = "[zabbix_template.template_test_1.id]"
+	}
+	return fmt.Sprintf(`
+	data "zabbix_server" "test" {}
+
+	resource "zabbix_template_group" "host_group_test" {
+		name = "host_group_%s"
+	}
+
+	resource "zabbix_template" "template_test_1" {
+		host = "template_%s_1"
+		groups = [zabbix_template_group.host_group_test.name]
+	}
+
+	resource "zabbix_item" "item_test" {
+		name = "item_%s"
+		key = "unlink.test"
+		delay = "60"
+		trends = join("", ["30", data.zabbix_server.test.unit_time_days])
+		history = join("", ["7", data.zabbix_server.test.unit_time_days])
+		host_id = zabbix_template.template_test_1.id
+	}
+
+	resource "zabbix_template" "template_test_2" {
+		host = "template_%s_2"
+		groups = [zabbix_template_group.host_group_test.name]
+		linked_template = %s
+		clear_on_unlink = %t
+		depends_on = [zabbix_item.item_test]
+	}
+	`, strID, strID, strID, strID, linkedTemplate, clearOnUnlink)
+}
+
+func testAccZabbixTemplateSecretMacro(strID, password string) string {
+	return fmt.Sprintf(`
+	resource "zabbix_template_group" "host_group_test" {
+		name = "host_group_%s"
+	}
+
+	resource "zabbix_template" "template_test" {
+		host = "template_%s"
+		groups = [zabbix_template_group.host_group_test.name]
+
+		macro {
+			name = "PASSWORD"
+			value = "%s"
+			type = 1
+			description = "database password"
+		}
+
+		macro {
+			name = "USER"
+			value = "zabbix"
+		}
+	}
+	`, strID, strID, password)
+}
@@ -1,23 +1,24 @@
 package zabbix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
 	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceZabbixTrigger() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceZabbixTriggerCreate,
-		Read:   resourceZabbixTriggerRead,
-		Exists: resourceZabbixTriggerExists,
-		Update: resourceZabbixTriggerUpdate,
-		Delete: resourceZabbixTriggerDelete,
+		CreateContext: resourceZabbixTriggerCreate,
+		ReadContext:   resourceZabbixTriggerRead,
+		UpdateContext: resourceZabbixTriggerUpdate,
+		DeleteContext: resourceZabbixTriggerDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"description": &schema.Schema{
@@ -66,31 +67,39 @@ func resourceZabbixTrigger() *schema.Resource {
 	}
 }
 
-func resourceZabbixTriggerCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceZabbixTriggerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
 	trigger := createTriggerObj(d)
 
-	return createRetry(d, meta, createTrigger, trigger, resourceZabbixTriggerRead)
+	return createRetry(ctx, d, meta, createTrigger, trigger, resourceZabbixTriggerRead)
 }
 
-func resourceZabbixTriggerRead(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixTriggerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
+	api := client.api
 
 	params := zabbix.Params{
 		"output":             "extend",
 		"selectDependencies": "extend",
 		"selectFunctions":    "extend",
 		"selectItems":        "extend",
+		"selectHosts":        []string{"hostid"},
 		"triggerids":         d.Id(),
 	}
 	res, err := api.TriggersGet(params)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	if len(res) != 1 {
-		return fmt.Errorf("Expected one result got : %d", len(res))
+	err = expectOneResult("Trigger", d.Id(), len(res))
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "Trigger", err))
 	}
 	trigger := res[0]
-	err = getTriggerExpression(&trigger, api)
+	err = getTriggerExpression(&trigger, api, client.cache)
+	if err != nil {
+		return diagFromErr(err)
+	}
 	log.Printf("[DEBUG] trigger expression: %s", trigger.Expression)
 	d.Set("description", trigger.Description)
 	d.Set("expression", trigger.Expression)
@@ -108,34 +117,22 @@ func resourceZabbixTriggerRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceZabbixTriggerExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	api := meta.(*zabbix.API)
-
-	_, err := api.TriggerGetByID(d.Id())
-	if err != nil {
-		if strings.Contains(err.Error(), "Expected exactly one result") {
-			log.Printf("[DEBUG] Trigger with id %s doesn't exist", d.Id())
-			return false, nil
-		}
-		return false, err
-	}
-	return true, nil
-}
-
-func resourceZabbixTriggerUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceZabbixTriggerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
 	trigger := createTriggerObj(d)
 
 	trigger.TriggerID = d.Id()
 	if !d.HasChange("dependencies") {
 		trigger.Dependencies = nil
 	}
-	return createRetry(d, meta, updateTrigger, trigger, resourceZabbixTriggerRead)
+	return createRetry(ctx, d, meta, updateTrigger, trigger, resourceZabbixTriggerRead)
 }
 
-func resourceZabbixTriggerDelete(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixTriggerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
 
-	return deleteRetry(d.Id(), getTriggerParentID, api.TriggersDeleteIDs, api)
+	return diagFromErr(deleteRetry(ctx, d.Id(), getTriggerParentID, api.TriggersDeleteIDs, api))
 }
 
 func createTriggerDependencies(d *schema.ResourceData) zabbix.Triggers {
@@ -160,30 +157,40 @@ func createTriggerObj(d *schema.ResourceData) zabbix.Trigger {
 	}
 }
 
-func getTriggerExpression(trigger *zabbix.Trigger, api *zabbix.API) error {
-	for _, function := range trigger.Functions {
-		var item zabbix.Item
-
-		items, err := api.ItemsGet(zabbix.Params{
-			"output":      "extend",
-			"selectHosts": "extend",
-			"itemids":     function.ItemID,
-		})
-		if err != nil {
-			return err
-		}
-		if len(items) != 1 {
-			return fmt.Errorf("Expected one item with id : %s and got : %d", function.ItemID, len(items))
+func getTriggerExpression(trigger *zabbix.Trigger, api *zabbix.API, cache *lookupCache) (err error) {
+	trigger.Expression, err = expandTriggerExpression(trigger.Expression, trigger.Functions, trigger.ParentHosts, "item.get", api, cache)
+	return
+}
+
+// expandTriggerExpression replaces the function IDs of expression with the host,
+// the key of the items and the functions, the items are resolved with the cache
+func expandTriggerExpression(expression string, functions zabbix.TriggerFunctions, hosts zabbix.Hosts, method string, api *zabbix.API, cache *lookupCache) (string, error) {
+	itemIDs := make([]string, len(functions))
+	for i, function := range functions {
+		itemIDs[i] = function.ItemID
+	}
+	hostIDs := make([]string, len(hosts))
+	for i, host := range hosts {
+		hostIDs[i] = host.HostID
+	}
+
+	items, err := cache.getItems(api, method, hostIDs, itemIDs)
+	if err != nil {
+		return "", err
+	}
+	for _, function := range functions {
+		item, ok := items[function.ItemID]
+		if !ok {
+			return "", fmt.Errorf("Expected one item with id : %s and got : 0", function.ItemID)
 		}
-		item = items[0]
-		if len(item.ItemParent) != 1 {
-			return fmt.Errorf("Expected one parent host for item with id %s, and got : %d", function.ItemID, len(item.ItemParent))
+		if len(item.Hosts) != 1 {
+			return "", fmt.Errorf("Expected one parent host for item with id %s, and got : %d", function.ItemID, len(item.Hosts))
 		}
 		idstr := fmt.Sprintf("{%s}", function.FunctionID)
-		expendValue := fmt.Sprintf("{%s:%s.%s(%s)}", item.ItemParent[0].Host, item.Key, function.Function, function.Parameter)
-		trigger.Expression = strings.Replace(trigger.Expression, idstr, expendValue, 1)
+		expendValue := fmt.Sprintf("{%s:%s.%s(%s)}", item.Hosts[0].Host, item.Key, function.Function, function.Parameter)
+		expression = strings.Replace(expression, idstr, expendValue, 1)
 	}
-	return nil
+	return expression, nil
 }
 
 func getTriggerParentID(api *zabbix.API, id string) (string, error) {
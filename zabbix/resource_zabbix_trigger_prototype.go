@@ -1,23 +1,23 @@
 package zabbix
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceZabbixTriggerPrototype() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceZabbixTriggerPrototypeCreate,
-		Read:   resourceZabbixTriggerPrototypeRead,
-		Exists: resourceZabbixTriggerPrototypeExist,
-		Update: resourceZabbixTriggerPrototypeUpdate,
-		Delete: resourceZabbixTriggerPrototypeDelete,
+		CreateContext: resourceZabbixTriggerPrototypeCreate,
+		ReadContext:   resourceZabbixTriggerPrototypeRead,
+		UpdateContext: resourceZabbixTriggerPrototypeUpdate,
+		DeleteContext: resourceZabbixTriggerPrototypeDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"description": &schema.Schema{
@@ -62,31 +62,39 @@ func resourceZabbixTriggerPrototype() *schema.Resource {
 	}
 }
 
-func resourceZabbixTriggerPrototypeCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceZabbixTriggerPrototypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
 	trigger := createTriggerPrototypeObj(d)
 
-	return createRetry(d, meta, createTriggerPrototype, trigger, resourceZabbixTriggerPrototypeRead)
+	return createRetry(ctx, d, meta, createTriggerPrototype, trigger, resourceZabbixTriggerPrototypeRead)
 }
 
-func resourceZabbixTriggerPrototypeRead(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixTriggerPrototypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	client := meta.(*providerClient)
+	api := client.api
 
 	params := zabbix.Params{
 		"output":             "extend",
 		"selectDependencies": "extend",
 		"selectFunctions":    "extend",
 		"selectItems":        "extend",
+		"selectHosts":        []string{"hostid"},
 		"triggerids":         d.Id(),
 	}
 	res, err := api.TriggerPrototypesGet(params)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
-	if len(res) != 1 {
-		return fmt.Errorf("Expected one result got : %d", len(res))
+	err = expectOneResult("Trigger prototype", d.Id(), len(res))
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "Trigger prototype", err))
 	}
 	trigger := res[0]
-	err = getTriggerPrototypeExpression(&trigger, api)
+	err = getTriggerPrototypeExpression(&trigger, api, client.cache)
+	if err != nil {
+		return diagFromErr(err)
+	}
 	log.Printf("[DEBUG] trigger expression: %s", trigger.Expression)
 	d.Set("description", trigger.Description)
 	d.Set("expression", trigger.Expression)
@@ -101,33 +109,21 @@ func resourceZabbixTriggerPrototypeRead(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func resourceZabbixTriggerPrototypeExist(d *schema.ResourceData, meta interface{}) (bool, error) {
-	api := meta.(*zabbix.API)
-
-	_, err := api.TriggerPrototypeGetByID(d.Id())
-	if err != nil {
-		if strings.Contains(err.Error(), "Expected exactly one result") {
-			log.Printf("Trigger prototype with id %s doesn't exist", d.Id())
-			return false, nil
-		}
-		return false, err
-	}
-	return true, nil
-}
-
-func resourceZabbixTriggerPrototypeUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceZabbixTriggerPrototypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
 	trigger := createTriggerPrototypeObj(d)
 	trigger.TriggerID = d.Id()
 	if !d.HasChange("dependencies") {
 		trigger.Dependencies = nil
 	}
-	return createRetry(d, meta, updateTriggerPrototype, trigger, resourceZabbixTriggerPrototypeRead)
+	return createRetry(ctx, d, meta, updateTriggerPrototype, trigger, resourceZabbixTriggerPrototypeRead)
 }
 
-func resourceZabbixTriggerPrototypeDelete(d *schema.ResourceData, meta interface{}) error {
-	api := meta.(*zabbix.API)
+func resourceZabbixTriggerPrototypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
 
-	return deleteRetry(d.Id(), getTriggerPrototypeParentID, api.TriggerPrototypesDeleteIDs, api)
+	return diagFromErr(deleteRetry(ctx, d.Id(), getTriggerPrototypeParentID, api.TriggerPrototypesDeleteIDs, api))
 }
 
 func createTriggerPrototypeDependencies(d *schema.ResourceData) zabbix.TriggerPrototypes {
@@ -151,30 +147,9 @@ func createTriggerPrototypeObj(d *schema.ResourceData) zabbix.TriggerPrototype {
 	}
 }
 
-func getTriggerPrototypeExpression(trigger *zabbix.TriggerPrototype, api *zabbix.API) error {
-	for _, function := range trigger.Functions {
-		var item zabbix.ItemPrototype
-
-		items, err := api.ItemPrototypesGet(zabbix.Params{
-			"output":      "extend",
-			"selectHosts": "extend",
-			"itemids":     function.ItemID,
-		})
-		if err != nil {
-			return err
-		}
-		if len(items) != 1 {
-			return fmt.Errorf("Expected one item with id : %s and got : %d", function.ItemID, len(items))
-		}
-		item = items[0]
-		if len(item.Hosts) != 1 {
-			return fmt.Errorf("Expected one parent host for item with id %s, and got : %d", function.ItemID, len(item.Hosts))
-		}
-		idstr := fmt.Sprintf("{%s}", function.FunctionID)
-		expendValue := fmt.Sprintf("{%s:%s.%s(%s)}", item.Hosts[0].Host, item.Key, function.Function, function.Parameter)
-		trigger.Expression = strings.Replace(trigger.Expression, idstr, expendValue, 1)
-	}
-	return nil
+func getTriggerPrototypeExpression(trigger *zabbix.TriggerPrototype, api *zabbix.API, cache *lookupCache) (err error) {
+	trigger.Expression, err = expandTriggerExpression(trigger.Expression, trigger.Functions, trigger.ParentHosts, "itemprototype.get", api, cache)
+	return
 }
 
 func getTriggerPrototypeParentID(api *zabbix.API, id string) (string, error) {
@@ -105,7 +105,7 @@ func TestAccZabbixTriggerPrototype_ExpressionUpdate(t *testing.T) {
 }
 
 func testAccCheckZabbixTriggerPrototypeDestroy(s *terraform.State) error {
-	api := testAccProvider.Meta().(*zabbix.API)
+	api := testAccProvider.Meta().(*providerClient).api
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "zabbix_trigger_prototype" {
@@ -127,13 +127,13 @@ func testAccCheckZabbixTriggerPrototypeDestroy(s *terraform.State) error {
 
 func testAccZabbixTriggerPrototypeConfig(groupName, templateName string) string {
 	return fmt.Sprintf(`
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name for template test %s"
 	  	}
 
@@ -175,13 +175,13 @@ func testAccZabbixTriggerPrototypeConfig(groupName, templateName string) string
 
 func testAccZabbixTriggerPrototypeUpdateConfig(groupName, templateName string) string {
 	return fmt.Sprintf(`
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name for template test %s"
 	  	}
 
@@ -223,13 +223,13 @@ func testAccZabbixTriggerPrototypeUpdateConfig(groupName, templateName string) s
 
 func testAccZabbixTriggerPrototypeDependenciesConfig(groupName, templateName string) string {
 	return fmt.Sprintf(`
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name for template test %s"
 	  	}
 
@@ -279,13 +279,13 @@ func testAccZabbixTriggerPrototypeDependenciesConfig(groupName, templateName str
 
 func testAccZabbixTriggerPrototypeUpdateKeyConfig(groupName, templateName string) string {
 	return fmt.Sprintf(`
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s_update"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name for template test %s"
 	  	}
 
@@ -326,13 +326,13 @@ func testAccZabbixTriggerPrototypeUpdateKeyConfig(groupName, templateName string
 
 func testAccZabbixTriggerPrototypeUpdateKeyConfig2(groupName, templateName string) string {
 	return fmt.Sprintf(`
-		resource "zabbix_host_group" "zabbix" {
+		resource "zabbix_template_group" "zabbix" {
 			name = "host group test %s"
 		}
 
 		resource "zabbix_template" "template_test" {
 			host = "%s_update"
-			groups = ["${zabbix_host_group.zabbix.name}"]
+			groups = ["${zabbix_template_group.zabbix.name}"]
 			name = "display name for template test %s"
 	  	}
 
@@ -373,7 +373,7 @@ func testAccZabbixTriggerPrototypeUpdateKeyConfig2(groupName, templateName strin
 
 func checkServerTriggerPrototypeDependencies() resource.TestCheckFunc {
 	return func(state *terraform.State) error {
-		api := testAccProvider.Meta().(*zabbix.API)
+		api := testAccProvider.Meta().(*providerClient).api
 
 		trigger0, ok := state.RootModule().Resources["zabbix_trigger_prototype.trigger_prototype_test_0"]
 		if !ok {
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/claranet/go-zabbix-api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -109,7 +108,7 @@ func TestAccZabbixTrigger_BasicDependencies(t *testing.T) {
 }
 
 func testAccCheckZabbixTriggerDestroy(s *terraform.State) error {
-	api := testAccProvider.Meta().(*zabbix.API)
+	api := testAccProvider.Meta().(*providerClient).api
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "zabbix_trigger" {
@@ -135,13 +134,13 @@ func testAccZabbixTriggerSimpleConfig(strID string) string {
 		compare_version = "3.4.0"
 	}
 
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 		description = "description for template"
 	  }
 
@@ -172,13 +171,13 @@ func testAccZabbixTriggerSimpleConfigUpdate(strID string) string {
 		compare_version = "3.4.0"
 	}
 
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 		description = "description for template"
 	  }
 
@@ -207,13 +206,13 @@ func testAccZabbixTriggerOmitEmpty(strID string) string {
 		compare_version = "3.4.0"
 	}
 
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 		description = "description for template"
 	  }
 
@@ -241,17 +240,21 @@ func testAccZabbixTriggerMacroConfig(strID string) string {
 		compare_version = "3.4.0"
 	}
 
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 		description = "description for template"
-		macro = {
-			MACRO_TRIGGER = "12m",
-			MACRO_UPDATE = "21m",
+		macro {
+			name = "MACRO_TRIGGER"
+			value = "12m"
+		}
+		macro {
+			name = "MACRO_UPDATE"
+			value = "21m"
 		}
 	  }
 
@@ -282,17 +285,21 @@ func testAccZabbixTriggerMacroConfigUpdate(strID string) string {
 		compare_version = "3.4.0"
 	}
 
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 		description = "description for template"
-		macro = {
-			MACRO_TRIGGER = "12m",
-			MACRO_UPDATE = "21m",
+		macro {
+			name = "MACRO_TRIGGER"
+			value = "12m"
+		}
+		macro {
+			name = "MACRO_UPDATE"
+			value = "21m"
 		}
 	  }
 
@@ -323,13 +330,13 @@ func testAccZabbixTriggerDependencies(strID string) string {
 		compare_version = "3.4.0"
 	}
 
-	resource "zabbix_host_group" "host_group_test" {
+	resource "zabbix_template_group" "host_group_test" {
 		name = "host_group_%s"
 	}
 
 	resource "zabbix_template" "template_test" {
 		host = "template_%s"
-		groups = ["${zabbix_host_group.host_group_test.name}"]
+		groups = ["${zabbix_template_group.host_group_test.name}"]
 		description = "description for template"
 	  }
 
@@ -0,0 +1,259 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// valueMapObject represent Zabbix value map object
+// https://www.zabbix.com/documentation/current/manual/api/reference/valuemap/object
+type valueMapObject struct {
+	ValueMapID string            `json:"valuemapid,omitempty"`
+	HostID     string            `json:"hostid,omitempty"`
+	Name       string            `json:"name"`
+	Mappings   []valueMapMapping `json:"mappings"`
+}
+
+type valueMapMapping struct {
+	Type     string `json:"type,omitempty"`
+	Value    string `json:"value"`
+	NewValue string `json:"newvalue"`
+}
+
+func resourceZabbixValueMap() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZabbixValueMapCreate,
+		ReadContext:   resourceZabbixValueMapRead,
+		UpdateContext: resourceZabbixValueMapUpdate,
+		DeleteContext: resourceZabbixValueMapDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the value map.",
+			},
+			"host_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "ID of the host or template that the value map belongs to. Required from Zabbix 5.4, value maps are global before.",
+			},
+			"mapping": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     schemaValueMapMapping(),
+				Required: true,
+			},
+		},
+	}
+}
+
+func schemaValueMapMapping() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"value": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Original value.",
+			},
+			"newvalue": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Value to which the original value is mapped to.",
+			},
+			"type": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Mapping match type (Zabbix 6.0+).",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(int)
+					if v < 0 || v > 5 {
+						errs = append(errs, fmt.Errorf("%q, must be between 0 and 5 inclusive, got %d", key, v))
+					}
+					return
+				},
+			},
+		},
+	}
+}
+
+func resourceZabbixValueMapCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateValueMaps()
+	valueMap, err := createValueMapObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	return createRetry(ctx, d, meta, createValueMap, *valueMap, resourceZabbixValueMapRead)
+}
+
+func resourceZabbixValueMapRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	api := meta.(*providerClient).api
+
+	valueMap, err := getValueMapByID(d.Id(), api)
+	if err != nil {
+		return diagFromErr(removeFromStateIfNotFound(d, "Value map", err))
+	}
+
+	d.Set("name", valueMap.Name)
+	d.Set("host_id", valueMap.HostID)
+
+	terraformMappings := make([]interface{}, len(valueMap.Mappings))
+	for i, mapping := range valueMap.Mappings {
+		mappingType, _ := strconv.Atoi(mapping.Type)
+		terraformMappings[i] = map[string]interface{}{
+			"value":    mapping.Value,
+			"newvalue": mapping.NewValue,
+			"type":     mappingType,
+		}
+	}
+	d.Set("mapping", terraformMappings)
+
+	log.Printf("[DEBUG] Value map name is %s\n", valueMap.Name)
+	return nil
+}
+
+func resourceZabbixValueMapUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateValueMaps()
+	valueMap, err := createValueMapObject(d, getZabbixServerVersion(meta))
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	valueMap.ValueMapID = d.Id()
+	// the host of a value map can't be updated
+	valueMap.HostID = ""
+	return createRetry(ctx, d, meta, updateValueMap, *valueMap, resourceZabbixValueMapRead)
+}
+
+func resourceZabbixValueMapDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	meta = withContext(ctx, d, meta)
+	defer meta.(*providerClient).cache.invalidateValueMaps()
+	api := meta.(*providerClient).api
+
+	_, err := api.CallWithError("valuemap.delete", []string{d.Id()})
+	return diagFromErr(err)
+}
+
+func createValueMapObject(d *schema.ResourceData, zabbixVersion string) (*valueMapObject, error) {
+	valueMap := valueMapObject{
+		Name:   d.Get("name").(string),
+		HostID: d.Get("host_id").(string),
+	}
+
+	hostScoped := isZabbixServerVersionGreaterOrEqual(zabbixVersion, "5.4.0")
+	if hostScoped && valueMap.HostID == "" {
+		return nil, attributeErrorf("host_id", "host_id is required for value maps on Zabbix 5.4 or higher, server version is %s", zabbixVersion)
+	}
+	if !hostScoped && valueMap.HostID != "" {
+		return nil, attributeErrorf("host_id", "host_id is only supported for value maps on Zabbix 5.4 or higher, server version is %s", zabbixVersion)
+	}
+
+	typedMappings := isZabbixServerVersionGreaterOrEqual(zabbixVersion, "6.0.0")
+	for _, terraformMapping := range d.Get("mapping").([]interface{}) {
+		value := terraformMapping.(map[string]interface{})
+		mapping := valueMapMapping{
+			Value:    value["value"].(string),
+			NewValue: value["newvalue"].(string),
+		}
+		if typedMappings {
+			mapping.Type = strconv.Itoa(value["type"].(int))
+		} else if value["type"].(int) != 0 {
+			return nil, attributeErrorf("mapping", "Mapping type requires Zabbix 6.0 or higher, server version is %s", zabbixVersion)
+		}
+		valueMap.Mappings = append(valueMap.Mappings, mapping)
+	}
+	return &valueMap, nil
+}
+
+func getValueMapByID(id string, api *zabbix.API) (*valueMapObject, error) {
+	var valueMaps []valueMapObject
+
+	err := api.CallWithErrorParse("valuemap.get", zabbix.Params{
+		"valuemapids":    id,
+		"output":         "extend",
+		"selectMappings": "extend",
+	}, &valueMaps)
+	if err != nil {
+		return nil, err
+	}
+	err = expectOneResult("Value map", id, len(valueMaps))
+	if err != nil {
+		return nil, err
+	}
+	return &valueMaps[0], nil
+}
+
+// getValueMapID resolves the ID of a value map given either its ID or its name.
+// From Zabbix 5.4, value maps names are only unique per host so hostID is used
+// to narrow the search.
+func getValueMapID(api *zabbix.API, cache *lookupCache, zabbixVersion, hostID, valueMapRef string) (string, error) {
+	if valueMapRef == "" {
+		return "0", nil
+	}
+	if _, err := strconv.Atoi(valueMapRef); err == nil {
+		return valueMapRef, nil
+	}
+
+	ids, err := cache.getValueMapIDs(api, zabbixVersion, hostID, []string{valueMapRef})
+	if err != nil {
+		return "", err
+	}
+	id, ok := ids[valueMapRef]
+	if !ok {
+		return "", fmt.Errorf("Expected one value map named %s and got 0", valueMapRef)
+	}
+	return id, nil
+}
+
+// createTerraformValueMap returns the valuemap reference to store in the state:
+// the configured reference when it still resolves to valueMapID, the ID otherwise
+func createTerraformValueMap(d *schema.ResourceData, api *zabbix.API, cache *lookupCache, zabbixVersion, valueMapID string) string {
+	if valueMapID == "0" {
+		valueMapID = ""
+	}
+
+	configured := d.Get("valuemap").(string)
+	if configured == "" || configured == valueMapID {
+		return valueMapID
+	}
+	if id, err := getValueMapID(api, cache, zabbixVersion, d.Get("host_id").(string), configured); err == nil && id == valueMapID {
+		return configured
+	}
+	return valueMapID
+}
+
+func createValueMap(valueMap interface{}, api *zabbix.API) (id string, err error) {
+	response, err := api.CallWithError("valuemap.create", []valueMapObject{valueMap.(valueMapObject)})
+	if err != nil {
+		return
+	}
+
+	result := response.Result.(map[string]interface{})
+	id = result["valuemapids"].([]interface{})[0].(string)
+	return
+}
+
+func updateValueMap(valueMap interface{}, api *zabbix.API) (id string, err error) {
+	zabbixValueMap := valueMap.(valueMapObject)
+
+	_, err = api.CallWithError("valuemap.update", []valueMapObject{zabbixValueMap})
+	if err != nil {
+		return
+	}
+	id = zabbixValueMap.ValueMapID
+	return
+}
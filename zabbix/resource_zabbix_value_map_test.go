@@ -0,0 +1,101 @@
+package zabbix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccZabbixValueMap_Basic(t *testing.T) {
+	strID := acctest.RandString(5)
+	valueMapName := fmt.Sprintf("value_map_%s", strID)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZabbixValueMapDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZabbixValueMapConfig(strID, valueMapName, "Up"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_value_map.service_state", "name", valueMapName),
+					resource.TestCheckResourceAttr("zabbix_value_map.service_state", "mapping.#", "2"),
+					resource.TestCheckResourceAttr("zabbix_value_map.service_state", "mapping.0.value", "0"),
+					resource.TestCheckResourceAttr("zabbix_value_map.service_state", "mapping.0.newvalue", "Down"),
+					resource.TestCheckResourceAttr("zabbix_value_map.service_state", "mapping.1.newvalue", "Up"),
+					resource.TestCheckResourceAttr("zabbix_item.service_state", "valuemap", valueMapName),
+					resource.TestCheckResourceAttrPair("zabbix_item.service_state", "valuemap_id", "zabbix_value_map.service_state", "id"),
+				),
+			},
+			{
+				Config: testAccZabbixValueMapConfig(strID, valueMapName, "Running"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zabbix_value_map.service_state", "mapping.1.newvalue", "Running"),
+					resource.TestCheckResourceAttrPair("zabbix_item.service_state", "valuemap_id", "zabbix_value_map.service_state", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckZabbixValueMapDestroy(s *terraform.State) error {
+	api := testAccProvider.Meta().(*providerClient).api
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "zabbix_value_map" {
+			continue
+		}
+
+		_, err := getValueMapByID(rs.Primary.ID, api)
+		if err == nil {
+			return fmt.Errorf("Value map still exists %s", rs.Primary.ID)
+		}
+		if !isNotFoundError(err) {
+			return fmt.Errorf("expected a not found error, got : %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func testAccZabbixValueMapConfig(strID, valueMapName, upValue string) string {
+	return fmt.Sprintf(`
+		data "zabbix_server" "test" {
+			compare_version = "5.4.0"
+		}
+
+		resource "zabbix_template_group" "zabbix" {
+			name = "host group %s"
+		}
+
+		resource "zabbix_template" "template_test" {
+			host = "template_%s"
+			groups = [zabbix_template_group.zabbix.name]
+		}
+
+		resource "zabbix_value_map" "service_state" {
+			name = "%s"
+			host_id = data.zabbix_server.test.server_version_ge ? zabbix_template.template_test.id : null
+
+			mapping {
+				value = "0"
+				newvalue = "Down"
+			}
+
+			mapping {
+				value = "1"
+				newvalue = "%s"
+			}
+		}
+
+		resource "zabbix_item" "service_state" {
+			name = "service state"
+			key = "service.state"
+			value_type = 3
+			host_id = zabbix_template.template_test.id
+			valuemap = zabbix_value_map.service_state.name
+		}
+	`, strID, strID, valueMapName, upValue)
+}
@@ -0,0 +1,98 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serverConfig is the configuration of a Zabbix server of the provider
+type serverConfig struct {
+	url      string
+	user     string
+	password string
+	token    string
+	http     httpConfig
+}
+
+// resourceGetter is a *schema.ResourceData or a *schema.ResourceDiff
+type resourceGetter interface {
+	Get(key string) interface{}
+}
+
+// addServerArgument adds the server argument selecting the server of a resource,
+// changing the server of a resource creates it on the new server
+func addServerArgument(r *schema.Resource, isResource bool) {
+	r.Schema["server"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    isResource,
+		Description: "Name of the server configured in the provider, the default server when empty.",
+	}
+	if !isResource {
+		return
+	}
+
+	if r.CustomizeDiff != nil {
+		r.CustomizeDiff = customdiff.All(customizeDiffServer, r.CustomizeDiff)
+	} else {
+		r.CustomizeDiff = customizeDiffServer
+	}
+	if r.Importer != nil {
+		r.Importer.StateContext = importStateWithServer(r.Importer.StateContext)
+	}
+}
+
+// selectServer returns the client of the server of the resource, the requests to
+// a server missing from the provider configuration fail
+func selectServer(d resourceGetter, client *providerClient) *providerClient {
+	name, _ := d.Get("server").(string)
+	if client.servers == nil && name == "" {
+		return client
+	}
+	if server, ok := client.servers[name]; ok {
+		return server
+	}
+	return &providerClient{
+		api:       client.api,
+		transport: unknownServerTransport(name),
+		cache:     newLookupCache(),
+		version:   &serverVersion{},
+		servers:   client.servers,
+	}
+}
+
+type unknownServerTransport string
+
+func (t unknownServerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("Server %s isn't configured in the provider", string(t))
+}
+
+func customizeDiffServer(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("server") {
+		return nil
+	}
+	name := d.Get("server").(string)
+	if _, ok := meta.(*providerClient).servers[name]; !ok && name != "" {
+		return fmt.Errorf("Server %s isn't configured in the provider", name)
+	}
+	return nil
+}
+
+// importStateWithServer imports the resources of a server with an ID prefixed
+// with the server name, "europe:10084"
+func importStateWithServer(importer schema.StateContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		if i := strings.Index(d.Id(), ":"); i > 0 {
+			if _, ok := meta.(*providerClient).servers[d.Id()[:i]]; ok {
+				d.Set("server", d.Id()[:i])
+				d.SetId(d.Id()[i+1:])
+			}
+		}
+		return importer(ctx, d, meta)
+	}
+}
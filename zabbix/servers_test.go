@@ -0,0 +1,47 @@
+package zabbix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/claranet/go-zabbix-api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSelectServer(t *testing.T) {
+	defaultServer := &providerClient{api: zabbix.NewAPI("http://default/api_jsonrpc.php"), cache: newLookupCache()}
+	europe := &providerClient{api: zabbix.NewAPI("http://europe/api_jsonrpc.php"), cache: newLookupCache()}
+	servers := map[string]*providerClient{"": defaultServer, "europe": europe}
+	defaultServer.servers = servers
+	europe.servers = servers
+
+	r := resourceZabbixItem()
+	addServerArgument(r, true)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "item", "key": "key", "host_id": "10084"})
+	if client := selectServer(d, europe); client != defaultServer {
+		t.Fatal("expected the default server without server argument")
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "item", "key": "key", "host_id": "10084", "server": "europe"})
+	if client := selectServer(d, defaultServer); client != europe {
+		t.Fatal("expected the europe server")
+	}
+	if client := withContext(context.Background(), d, defaultServer).(*providerClient); client.cache != europe.cache {
+		t.Fatal("expected the operation to use the europe server")
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "item", "key": "key", "host_id": "10084", "server": "asia"})
+	if _, err := selectServer(d, defaultServer).transport.RoundTrip(nil); err == nil {
+		t.Fatal("expected the requests to an unknown server to fail")
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "item", "key": "key", "host_id": "10084"})
+	d.SetId("europe:10084")
+	if _, err := r.Importer.StateContext(context.Background(), d, defaultServer); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "10084" || d.Get("server").(string) != "europe" {
+		t.Fatalf("expected the server to be imported from the ID, got %s on %s", d.Id(), d.Get("server"))
+	}
+}
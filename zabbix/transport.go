@@ -0,0 +1,105 @@
+package zabbix
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// limitTransport caps the number of concurrent requests and the request rate of
+// all the resources of the provider, the requests wait in a queue for their turn
+type limitTransport struct {
+	transport http.RoundTripper
+	// slots is nil when the number of concurrent requests isn't capped
+	slots chan struct{}
+	// interval is 0 when the request rate isn't limited
+	interval time.Duration
+
+	mutex  sync.Mutex
+	next   time.Time
+	queued int32
+}
+
+func newLimitTransport(transport http.RoundTripper, maxConcurrentRequests int, requestsPerSecond float64) *limitTransport {
+	t := &limitTransport{transport: transport}
+	if maxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+	if requestsPerSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return t
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	queued := atomic.AddInt32(&t.queued, 1)
+	release, err := t.wait(req)
+	atomic.AddInt32(&t.queued, -1)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] Zabbix request waited %s in the queue, %d requests queued and %d in flight", time.Since(start), queued-1, len(t.slots))
+
+	res, err := t.transport.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	// the request is in flight until its response is read
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
+// wait blocks until the request can be sent, the returned function frees its slot
+func (t *limitTransport) wait(req *http.Request) (func(), error) {
+	ctx := req.Context()
+
+	release := func() {}
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		release = func() { <-t.slots }
+	}
+
+	if t.interval > 0 {
+		t.mutex.Lock()
+		now := time.Now()
+		if t.next.Before(now) {
+			t.next = now
+		}
+		delay := t.next.Sub(now)
+		t.next = t.next.Add(t.interval)
+		t.mutex.Unlock()
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+	return release, nil
+}
+
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
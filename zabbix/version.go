@@ -0,0 +1,143 @@
+package zabbix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serverVersion keeps the version of the Zabbix server, it is requested once by
+// the provider and shared by all the operations
+type serverVersion struct {
+	mutex   sync.Mutex
+	version string
+}
+
+// versionedAttribute is an attribute supported from minVersion and, when
+// removedVersion is set, removed in removedVersion. When value is set, only
+// this value of the attribute is versioned
+type versionedAttribute struct {
+	attribute      string
+	minVersion     string
+	removedVersion string
+	value          string
+}
+
+// customizeDiffVersions fails the plan when the resource, whose minimum version
+// is minVersion, or one of the configured attributes isn't supported by the server
+func customizeDiffVersions(resource, minVersion string, attributes ...versionedAttribute) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		zabbixVersion := getZabbixServerVersion(withContext(ctx, d, meta))
+		if zabbixVersion == "" {
+			// the operations report the error of the server
+			return nil
+		}
+
+		if minVersion != "" && !isZabbixServerVersionGreaterOrEqual(zabbixVersion, minVersion) {
+			return fmt.Errorf("%s requires Zabbix %s or higher, server version is %s", resource, shortVersion(minVersion), zabbixVersion)
+		}
+		for _, a := range attributes {
+			v, ok := d.GetOk(a.attribute)
+			if !ok {
+				continue
+			}
+			name := a.attribute
+			if a.value != "" {
+				if fmt.Sprint(v) != a.value {
+					continue
+				}
+				name = fmt.Sprintf("%s %s", a.attribute, a.value)
+			}
+			if a.minVersion != "" && !isZabbixServerVersionGreaterOrEqual(zabbixVersion, a.minVersion) {
+				return fmt.Errorf("%s requires Zabbix %s or higher, server version is %s", name, shortVersion(a.minVersion), zabbixVersion)
+			}
+			if a.removedVersion != "" && isZabbixServerVersionGreaterOrEqual(zabbixVersion, a.removedVersion) {
+				return fmt.Errorf("%s was removed in Zabbix %s, server version is %s", name, shortVersion(a.removedVersion), zabbixVersion)
+			}
+		}
+		return nil
+	}
+}
+
+// customizeDiffDays fails the plan when a number of days of the attributes
+// uses a time suffix that the server doesn't support
+func customizeDiffDays(attributes ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		zabbixVersion := getZabbixServerVersion(withContext(ctx, d, meta))
+		if zabbixVersion == "" || isZabbixServerVersion34OrHigher(zabbixVersion) {
+			return nil
+		}
+
+		for _, attribute := range attributes {
+			v := d.Get(attribute).(string)
+			if v != "" && !daysRegexp.MatchString(v) {
+				return fmt.Errorf("%s must be a number of days before Zabbix 3.4, server version is %s, got %s", attribute, zabbixVersion, v)
+			}
+		}
+		return nil
+	}
+}
+
+// customizeDiffDelays fails the plan when an update interval of the attributes
+// uses a time suffix that the server doesn't support
+func customizeDiffDelays(attributes ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		zabbixVersion := getZabbixServerVersion(withContext(ctx, d, meta))
+		if zabbixVersion == "" || isZabbixServerVersion34OrHigher(zabbixVersion) {
+			return nil
+		}
+
+		for _, attribute := range attributes {
+			v := d.Get(attribute).(string)
+			if hasDelaySuffix(v) {
+				return fmt.Errorf("%s must be a number of seconds without time suffix before Zabbix 3.4, server version is %s, got %s", attribute, zabbixVersion, v)
+			}
+		}
+		return nil
+	}
+}
+
+// daysRegexp matches the numbers of days accepted by every Zabbix version
+var daysRegexp = regexp.MustCompile(`^[0-9]+d?$`)
+
+// normalizeDays returns the number of days in the format of the server,
+// "90" before Zabbix 3.4 and "90d" since
+func normalizeDays(days, zabbixVersion string) string {
+	if !daysRegexp.MatchString(days) {
+		return days
+	}
+	return strings.TrimSuffix(days, "d") + getZabbixServerUnitDays(zabbixVersion)
+}
+
+// shortVersion returns "5.4" for "5.4.0"
+func shortVersion(v string) string {
+	return strings.TrimSuffix(v, ".0")
+}
+
+func getZabbixServerVersion(meta interface{}) string {
+	client := meta.(*providerClient)
+	if client.version != nil {
+		client.version.mutex.Lock()
+		defer client.version.mutex.Unlock()
+		if client.version.version != "" {
+			return client.version.version
+		}
+	}
+
+	v, err := client.api.Version()
+	if err != nil {
+		log.Printf("[WARN] Failed to get Zabbix Server version: %v\n", err)
+		return ""
+	}
+	log.Printf("[DEBUG] Zabbix Server version is %s\n", v)
+
+	if client.version != nil {
+		client.version.version = v
+	}
+	return v
+}
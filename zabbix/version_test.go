@@ -0,0 +1,47 @@
+package zabbix
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/claranet/go-zabbix-api"
+)
+
+func TestGetZabbixServerVersionCached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"jsonrpc":"2.0","result":"6.0.12","id":1}`))
+	}))
+	defer server.Close()
+
+	client := &providerClient{api: zabbix.NewAPI(server.URL), cache: newLookupCache(), version: &serverVersion{}}
+	for i := 0; i < 3; i++ {
+		if v := getZabbixServerVersion(client); v != "6.0.12" {
+			t.Fatalf("expected the server version, got %s", v)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected the server version to be requested once, got %d requests", requests)
+	}
+}
+
+func TestNormalizeDays(t *testing.T) {
+	cases := []struct {
+		days, zabbixVersion, expected string
+	}{
+		{"90", "3.2.0", "90"},
+		{"90d", "3.2.0", "90"},
+		{"90", "5.0.0", "90d"},
+		{"90d", "5.0.0", "90d"},
+		{"2w", "5.0.0", "2w"},
+		{"{$HISTORY}", "5.0.0", "{$HISTORY}"},
+	}
+	for _, c := range cases {
+		if v := normalizeDays(c.days, c.zabbixVersion); v != c.expected {
+			t.Errorf("expected %s for %s on Zabbix %s, got %s", c.expected, c.days, c.zabbixVersion, v)
+		}
+	}
+
+}